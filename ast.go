@@ -1,17 +1,137 @@
 package jo
 
 import (
+	"fmt"
 	"go/ast"
+	"go/token"
+	"sort"
+	"strings"
 )
 
+// validPos is a placeholder position for fields such as ast.CallExpr.Ellipsis, where only whether the position is
+// valid affects how the node is printed.
+const validPos = token.Pos(1)
+
+// declSpacing is the distance between the positions given to declarations which carry comments. It leaves room for
+// the printer to advance through the output of one declaration without reaching the comments of the next.
+const declSpacing = 1 << 20
+
+// Parse parses a Jo source file. It fails at the first declaration which cannot be parsed, whether or not it is the
+// first declaration in the file, rather than silently dropping the rest of the file.
 func Parse(input string) (*ast.File, error) {
-	_, node, err := SourceFile(NewSource(input))
+	output, node, err := SourceFile(NewSource(input))
 	if err != nil {
 		return nil, err
 	}
+	if !output.Finished() {
+		return nil, NewParseError(output.Offset, fmt.Sprintf("wanted a declaration, got %q", firstLine(output.Remaining())))
+	}
 	return node.(*ast.File), nil
 }
 
+// firstLine returns s up to the first newline.
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+// NewFileSet returns a file set for printing f, holding a file in which each position given to the package clause, a
+// declaration or a comment of f starts a new line, as does the position following each comment group. Without it,
+// the printer cannot tell where the comments in f.Comments belong.
+func NewFileSet(f *ast.File) *token.FileSet {
+	offsets := map[int]bool{int(validPos) - 1: true}
+	if f.Package.IsValid() {
+		offsets[int(f.Package)-1] = true
+	}
+	for _, group := range f.Comments {
+		for _, c := range group.List {
+			offsets[int(c.Slash)-1] = true
+		}
+		offsets[int(group.List[len(group.List)-1].Slash)] = true
+	}
+	for _, d := range f.Decls {
+		if d.Pos().IsValid() {
+			offsets[int(d.Pos())-1] = true
+		}
+	}
+	lines := make([]int, 0, len(offsets))
+	for offset := range offsets {
+		lines = append(lines, offset)
+	}
+	sort.Ints(lines)
+	fset := token.NewFileSet()
+	file := fset.AddFile("", -1, lines[len(lines)-1]+1)
+	file.SetLines(lines)
+	return fset
+}
+
+// newComments converts the text of a LineComment or BlockComment into Go line comments, one for each line of text.
+func newComments(text string) []*ast.Comment {
+	if strings.HasPrefix(text, ";") {
+		return commentLines(strings.TrimLeft(text, ";"))
+	}
+	return commentLines(strings.TrimSuffix(strings.TrimPrefix(text, "#|"), "|#"))
+}
+
+// commentLines returns a Go line comment for each line of text, without leading or trailing blank lines.
+func commentLines(text string) []*ast.Comment {
+	var comments []*ast.Comment
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			line = " " + line
+		}
+		comments = append(comments, &ast.Comment{Text: "//" + line})
+	}
+	return comments
+}
+
+// declDoc returns the doc comment of decl, or nil if it has none.
+func declDoc(decl ast.Decl) *ast.CommentGroup {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		return d.Doc
+	case *ast.GenDecl:
+		return d.Doc
+	}
+	return nil
+}
+
+// positionComments places the comments in groups on the lines before pos, leaving a blank line between groups.
+func positionComments(groups []*ast.CommentGroup, pos token.Pos) {
+	for i := len(groups) - 1; i >= 0; i-- {
+		list := groups[i].List
+		for j := len(list) - 1; j >= 0; j-- {
+			pos--
+			list[j].Slash = pos
+		}
+		pos--
+	}
+}
+
+// positionDecl places decl at pos, and the comments in groups, which end with the doc comment of decl if it has one,
+// on the lines before it. The closing brace of a function body is placed at validPos, on a line before its opening
+// brace, so that the printer neither collapses the body onto the line of the declaration nor leaves a blank line
+// before the closing brace.
+func positionDecl(decl ast.Decl, groups []*ast.CommentGroup, pos token.Pos) {
+	end := pos
+	if doc := declDoc(decl); doc != nil {
+		positionComments([]*ast.CommentGroup{doc}, end)
+		end -= token.Pos(len(doc.List))
+		groups = groups[:len(groups)-1]
+	}
+	positionComments(groups, end)
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		d.Type.Func = pos
+		d.Body.Lbrace = pos
+		d.Body.Rbrace = validPos
+	case *ast.GenDecl:
+		d.TokPos = pos
+	}
+}
+
 func newSelectorExpr(x, sel interface{}) *ast.SelectorExpr {
 	var expr ast.SelectorExpr
 	switch v := x.(type) {
@@ -41,3 +161,148 @@ func newCallExpr(fun interface{}, args ...ast.Expr) *ast.CallExpr {
 	}
 	return expr
 }
+
+// foldBinaryExpr joins operands with op into a left-associative tree of *ast.BinaryExpr.
+func foldBinaryExpr(op token.Token, operands []ast.Expr) ast.Expr {
+	expr := operands[0]
+	for _, y := range operands[1:] {
+		expr = &ast.BinaryExpr{
+			X:  expr,
+			Op: op,
+			Y:  y,
+		}
+	}
+	return expr
+}
+
+// isComparison reports whether op is a comparison operator.
+func isComparison(op token.Token) bool {
+	switch op {
+	case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+		return true
+	}
+	return false
+}
+
+// isPure reports whether evaluating x more than once is indistinguishable from evaluating it once, which holds
+// unless x contains a call or a receive. Pure operands are compared in place rather than bound to temporaries, which
+// also keeps untyped constants such as -1 from taking their default type.
+func isPure(x ast.Expr) bool {
+	switch v := x.(type) {
+	case *ast.Ident, *ast.BasicLit:
+		return true
+	case *ast.SelectorExpr:
+		return isPure(v.X)
+	case *ast.ParenExpr:
+		return isPure(v.X)
+	case *ast.StarExpr:
+		return isPure(v.X)
+	case *ast.UnaryExpr:
+		return v.Op != token.ARROW && isPure(v.X)
+	case *ast.BinaryExpr:
+		return isPure(v.X) && isPure(v.Y)
+	case *ast.IndexExpr:
+		return isPure(v.X) && isPure(v.Index)
+	}
+	return false
+}
+
+// isAssignable reports whether x is a form which can appear on the left of an assignment.
+func isAssignable(x ast.Expr) bool {
+	switch v := x.(type) {
+	case *ast.Ident, *ast.SelectorExpr, *ast.IndexExpr, *ast.StarExpr:
+		return true
+	case *ast.ParenExpr:
+		return isAssignable(v.X)
+	}
+	return false
+}
+
+// chainComparison expands a comparison of more than two operands, such as 0 < i < n, into pairwise comparisons of
+// neighbouring operands joined with &&. When an operand which is compared twice is not pure, every impure operand is
+// bound to a temporary inside an immediately invoked function literal, so that each operand is still evaluated
+// exactly once and from left to right. Each operand is bound just before its first comparison, and the function
+// returns as soon as a comparison fails, so that later operands are not evaluated, just as with &&.
+func chainComparison(op token.Token, operands []ast.Expr) ast.Expr {
+	var impure bool
+	for _, x := range operands[1 : len(operands)-1] {
+		impure = impure || !isPure(x)
+	}
+	if !impure {
+		return pairwise(op, operands)
+	}
+	used := make(map[string]bool)
+	for _, x := range operands {
+		ast.Inspect(x, func(node ast.Node) bool {
+			if ident, ok := node.(*ast.Ident); ok {
+				used[ident.Name] = true
+			}
+			return true
+		})
+	}
+	var stmts []ast.Stmt
+	var pending []ast.Expr
+	assign := &ast.AssignStmt{Tok: token.DEFINE}
+	bound := make([]ast.Expr, len(operands))
+	for i, x := range operands {
+		if isPure(x) {
+			bound[i] = x
+		} else {
+			if len(pending) > 0 {
+				stmts = append(stmts, &ast.IfStmt{
+					Cond: &ast.UnaryExpr{Op: token.NOT, X: &ast.ParenExpr{X: foldBinaryExpr(token.LAND, pending)}},
+					Body: &ast.BlockStmt{List: []ast.Stmt{
+						&ast.ReturnStmt{Results: []ast.Expr{ast.NewIdent("false")}},
+					}},
+				})
+				pending = nil
+			}
+			name := tempName(used)
+			assign.Lhs = append(assign.Lhs, ast.NewIdent(name))
+			assign.Rhs = append(assign.Rhs, x)
+			bound[i] = ast.NewIdent(name)
+		}
+		if i == 0 {
+			continue
+		}
+		if len(assign.Lhs) > 0 {
+			stmts = append(stmts, assign)
+			assign = &ast.AssignStmt{Tok: token.DEFINE}
+		}
+		pending = append(pending, &ast.BinaryExpr{X: bound[i-1], Op: op, Y: bound[i]})
+	}
+	stmts = append(stmts, &ast.ReturnStmt{Results: []ast.Expr{foldBinaryExpr(token.LAND, pending)}})
+	return &ast.CallExpr{
+		Fun: &ast.FuncLit{
+			Type: &ast.FuncType{
+				Params:  &ast.FieldList{},
+				Results: &ast.FieldList{List: []*ast.Field{{Type: ast.NewIdent("bool")}}},
+			},
+			Body: &ast.BlockStmt{List: stmts},
+		},
+	}
+}
+
+// pairwise compares each pair of neighbouring operands with op and joins the comparisons with &&.
+func pairwise(op token.Token, operands []ast.Expr) ast.Expr {
+	comparisons := make([]ast.Expr, len(operands)-1)
+	for i := range comparisons {
+		comparisons[i] = &ast.BinaryExpr{
+			X:  operands[i],
+			Op: op,
+			Y:  operands[i+1],
+		}
+	}
+	return foldBinaryExpr(token.LAND, comparisons)
+}
+
+// tempName returns the first name of the form cmp0, cmp1 and so on which is not in used, and marks it as used.
+func tempName(used map[string]bool) string {
+	for i := 0; ; i++ {
+		name := fmt.Sprintf("cmp%d", i)
+		if !used[name] {
+			used[name] = true
+			return name
+		}
+	}
+}
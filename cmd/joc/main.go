@@ -2,7 +2,6 @@ package main
 
 import (
 	"go/format"
-	"go/token"
 	"io/ioutil"
 	"os"
 
@@ -18,5 +17,5 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
-	format.Node(os.Stdout, token.NewFileSet(), ast)
+	format.Node(os.Stdout, jo.NewFileSet(ast), ast)
 }
@@ -0,0 +1,11 @@
+package main
+
+import "fmt"
+
+func main() {
+	a, b := 12, 5
+	fmt.Println(a-b, a&^b, a|b, a&b, a^b, a<<2, a>>1)
+	fmt.Println(a <= b, a >= b, a == b, a > b && b != 0, false || !true)
+	p := &a
+	fmt.Println(-a, +b, ^b, *p)
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+func main() {
+	count := 0
+	next := func() int {
+		count++
+		return count
+	}
+	fmt.Println(next(), next())
+	fmt.Println(strings.Map(func(r rune) rune {
+		return r + 1
+	}, "HAL"))
+	func(s string) {
+		fmt.Println(s)
+	}("invoked")
+}
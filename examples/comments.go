@@ -0,0 +1,22 @@
+// This file is part of the jo examples.
+
+// Package main shows how comments are carried over.
+package main
+
+// fmt is used to print the origin.
+import "fmt"
+
+// Point is a point in the plane.
+type Point struct {
+	X int
+	Y int
+}
+
+// origin is the zero Point.
+// #| Block comments nest. |#
+var origin Point
+
+// main prints the origin.
+func main() {
+	fmt.Println(origin)
+}
@@ -0,0 +1,18 @@
+package main
+
+import "fmt"
+
+type Point struct {
+	X int
+	Y int
+}
+
+func main() {
+	p := Point{X: 1, Y: 2}
+	q := &Point{3, 4}
+	path := []Point{{0, 0}, {X: 5, Y: 5}}
+	refs := []*Point{{1, 1}, {Y: 2}}
+	ages := map[string]int{"alice": 30, "bob": 25}
+	primes := [...]int{2, 3, 5, 7}
+	fmt.Println(p, q.X, path, refs[1].Y, ages, primes)
+}
@@ -0,0 +1,24 @@
+package main
+
+import "fmt"
+
+type Point struct {
+	X, Y int
+}
+
+func main() {
+	sum := 0
+	for _, x := range []int{1, 2, 3} {
+		sum += x
+	}
+	xs := []int{1, 2, 3}
+	for i := 0; i < len(xs); i += 1 {
+		xs[i] <<= 2
+	}
+	p := Point{}
+	p.X = 5
+	ptr := &p.Y
+	*ptr = -3
+	sum &^= 2
+	fmt.Println(sum, xs, p)
+}
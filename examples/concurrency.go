@@ -0,0 +1,30 @@
+package main
+
+import "fmt"
+
+func produce(out chan<- int, n int) {
+	for i := 0; i < n; i++ {
+		out <- i
+	}
+	close(out)
+}
+func main() {
+	ch := make(chan int)
+	done := make(chan bool)
+	go produce(ch, 3)
+	go func() {
+		for range ch {
+			fmt.Println("tick")
+		}
+		done <- true
+	}()
+	<-done
+	v, ok := <-ch
+	fmt.Println(v, ok)
+	select {
+	case v, ok := <-ch:
+		fmt.Println("received", v, ok)
+	default:
+		fmt.Println("nothing")
+	}
+}
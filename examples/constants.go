@@ -0,0 +1,25 @@
+package main
+
+import "fmt"
+
+type Weekday int
+
+const (
+	Sunday Weekday = iota
+	Monday
+	Tuesday
+)
+const greeting = "hello"
+
+var (
+	count int
+	names = []string{"a", "b"}
+)
+
+func main() {
+	const limit = 3
+	var x, y int = 1, 2
+	var total = x + y
+	count = len(names)
+	fmt.Println(greeting, Sunday, Monday, Tuesday, limit, total, count)
+}
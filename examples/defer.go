@@ -0,0 +1,18 @@
+package main
+
+import "fmt"
+
+func safeDivide(a, b int) int {
+	defer func() {
+		r := recover()
+		if r != nil {
+			fmt.Println("recovered:", r)
+		}
+	}()
+	return a / b
+}
+func main() {
+	defer fmt.Println("done")
+	fmt.Println(safeDivide(6, 3))
+	fmt.Println(safeDivide(1, 0))
+}
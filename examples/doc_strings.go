@@ -0,0 +1,31 @@
+package main
+
+import "fmt"
+
+// Directions of travel.
+const (
+	North = iota
+	South
+)
+
+// greeting is printed by main.
+var greeting = "hello"
+
+// Stack is a last-in, first-out collection.
+type Stack[T any] struct {
+	items []T
+}
+
+// Push adds v to the top of the stack.
+//
+// It never fails.
+func (s *Stack[T]) Push(v T) {
+	s.items = append(s.items, v)
+}
+
+// Comments and doc strings can be combined.
+// main prints a greeting.
+func main() {
+	var x int
+	fmt.Println(greeting, North, South, x)
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+)
+
+func main() {
+	for i := 1; i < 16; i++ {
+		if 0 == i%15 {
+			fmt.Println("fizzbuzz")
+		} else if 0 == i%3 {
+			fmt.Println("fizz")
+		} else if 0 == i%5 {
+			fmt.Println("buzz")
+		} else {
+			fmt.Println(i)
+		}
+	}
+	if n, err := strconv.Atoi("42"); err != nil {
+		fmt.Println(err)
+	} else {
+		fmt.Println(n)
+	}
+}
@@ -0,0 +1,10 @@
+package main
+
+import "fmt"
+
+func greet(greeting, name string) {
+	fmt.Println(greeting, name)
+}
+func main() {
+	greet("Hello,", "World")
+}
@@ -0,0 +1,34 @@
+package main
+
+import "fmt"
+
+type Number interface {
+	~int | ~float64
+}
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+func Sum[T Number](xs ...T) T {
+	var total T
+	for _, x := range xs {
+		total = total + x
+	}
+	return total
+}
+func Map[T, U any](xs []T, f func(T) U) []U {
+	out := make([]U, 0, len(xs))
+	for _, x := range xs {
+		out = append(out, f(x))
+	}
+	return out
+}
+func main() {
+	fmt.Println(Sum(1, 2, 3), Sum[float64](1.5, 2))
+	p := Pair[string, int]{"a", 1}
+	fmt.Println(p.Key, p.Value)
+	fmt.Println(Map[int, string]([]int{1, 2}, func(i int) string {
+		return fmt.Sprint(i)
+	}))
+}
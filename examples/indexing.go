@@ -0,0 +1,12 @@
+package main
+
+import "fmt"
+
+func main() {
+	primes := []int{2, 3, 5, 7, 11, 13}
+	ages := map[string]int{"alice": 30}
+	primes[0] = 1
+	age, ok := ages["bob"]
+	fmt.Println(primes[2], primes[1:3], primes[:2], primes[4:])
+	fmt.Println(cap(primes[0:2:4]), age, ok)
+}
@@ -0,0 +1,28 @@
+package main
+
+import "fmt"
+
+type Shape interface {
+	Area() float64
+	Perimeter() float64
+}
+type Number interface {
+	~int | ~float64
+}
+type Square struct {
+	Side float64
+}
+
+func (s Square) Area() float64 {
+	return s.Side * s.Side
+}
+func (s Square) Perimeter() float64 {
+	return 4 * s.Side
+}
+func describe(s Shape) {
+	fmt.Println(s.Area(), s.Perimeter())
+}
+func main() {
+	var s Square
+	describe(s)
+}
@@ -0,0 +1,10 @@
+package main
+
+import "fmt"
+
+func main() {
+	fmt.Println(0b1010, 0o17, 017, 0xFF, 1_000_000)
+	fmt.Println(1.5e3, .25, 0x1p-2, 2i, 1.5i)
+	fmt.Println('\x41', 'é', '\'', "tab\tquote\" é\U0001F600\101")
+	fmt.Println(`raw \n string with "quotes"`)
+}
@@ -0,0 +1,34 @@
+package main
+
+import "fmt"
+
+func main() {
+	n := 1
+	for n < 100 {
+		n = n * 2
+	}
+	fmt.Println(n)
+	for {
+		n--
+		if 0 == n%3 {
+			continue
+		}
+		if n < 90 {
+			break
+		}
+	}
+	fmt.Println(n)
+outer:
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			if j == 2 {
+				continue outer
+			} else {
+				if i == 2 {
+					break outer
+				}
+			}
+			fmt.Println(i, j)
+		}
+	}
+}
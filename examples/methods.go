@@ -0,0 +1,22 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+type Point struct {
+	X float64
+	Y float64
+}
+
+func (p *Point) Dist() float64 {
+	return math.Sqrt(p.X*p.X + p.Y*p.Y)
+}
+func (p Point) String() string {
+	return fmt.Sprintf("(%.1f, %.1f)", p.X, p.Y)
+}
+func main() {
+	var p Point
+	fmt.Println(p, p.Dist())
+}
@@ -0,0 +1,23 @@
+package main
+
+import "fmt"
+
+func main() {
+	sum := 0
+	for _, x := range []int{1, 2, 3} {
+		sum = sum + x
+	}
+	for k, v := range map[string]int{"a": 1} {
+		fmt.Println(k, v)
+	}
+	for i, r := range "jo" {
+		fmt.Println(i, string(r))
+	}
+	for i := range 2 {
+		fmt.Println(i)
+	}
+	for range [1]int{} {
+		fmt.Println("once")
+	}
+	fmt.Println(sum)
+}
@@ -0,0 +1,18 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+func div(a, b int) (q int, err error) {
+	if b == 0 {
+		return 0, errors.New("division by zero")
+	}
+	q = a / b
+	return
+}
+func main() {
+	q, err := div(7, 2)
+	fmt.Println(q, err)
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+type Base struct {
+	ID int "json:\"id\""
+}
+type User struct {
+	*Base
+	sync.Mutex
+	First, Last string
+	Age         int "json:\"age,omitempty\""
+}
+
+func main() {
+	u := &User{Base: &Base{7}, First: "Ada", Age: 36}
+	u.Lock()
+	b, _ := json.Marshal(u)
+	u.Unlock()
+	fmt.Println(string(b))
+	var point struct {
+		X, Y int
+	}
+	point.X = 1
+	pair := struct {
+		Key   string
+		Value int
+	}{"a", 1}
+	fmt.Println(point, pair.Key, pair.Value)
+}
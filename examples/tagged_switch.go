@@ -0,0 +1,34 @@
+package main
+
+import "fmt"
+
+func classify(n int) string {
+	switch n {
+	case 0:
+		return "zero"
+	case 1, 3, 5, 7, 9:
+		return "odd digit"
+	case 2, 4, 6, 8:
+		return "even digit"
+	default:
+		return "large"
+	}
+}
+func main() {
+	for i := 0; i < 11; i++ {
+		fmt.Println(i, classify(i))
+	}
+	switch x := 2; x {
+	case 2:
+		fmt.Println("two")
+		fallthrough
+	case 3:
+		fmt.Println("two or three")
+	}
+	switch y := 7; {
+	case y > 5:
+		fmt.Println("big")
+	default:
+		fmt.Println("small")
+	}
+}
@@ -0,0 +1,28 @@
+package main
+
+import "fmt"
+
+func describe(x interface{}) {
+	switch v := x.(type) {
+	case int:
+		fmt.Println("int", v+1)
+	case string, []byte:
+		fmt.Println("text", v)
+	case nil:
+		fmt.Println("nil")
+	default:
+		fmt.Println("other", v)
+	}
+}
+func main() {
+	describe(1)
+	describe("hello")
+	describe(nil)
+	describe(1.5)
+	var x interface{}
+	x = "jo"
+	s, ok := x.(string)
+	fmt.Println(s, ok)
+	n, ok2 := x.(int)
+	fmt.Println(n, ok2)
+}
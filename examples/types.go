@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+type Celsius float64
+type Handler func(w io.Writer, msg string)
+type Tree struct {
+	Left  *Tree
+	Right *Tree
+	Tags  map[string]int
+}
+
+func produce(out chan<- int) {
+	close(out)
+}
+func main() {
+	var t Tree
+	var buf []byte
+	var grid [2][2]int
+	var h Handler
+	var c Celsius
+	fmt.Println(t.Left, buf, grid, c, h == nil)
+}
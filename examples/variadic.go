@@ -0,0 +1,15 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+func join(sep string, elems ...string) string {
+	return strings.Join(elems, sep)
+}
+func main() {
+	words := strings.Fields("variadic functions in jo")
+	fmt.Println(join("-", "a", "b", "c"))
+	fmt.Println(join(" ", words...))
+}
@@ -0,0 +1,32 @@
+package main
+
+import "fmt"
+
+var calls int
+var (
+	lo float64 = -2.0
+	hi float64 = 3.0
+)
+
+func next() int {
+	calls++
+	return calls
+}
+func main() {
+	i, n := 3, 10
+	fmt.Println(1+2+3+4, 10-1-2, -i, 2*i*n)
+	fmt.Println(0 < i && i < n, i == 3 && 3 == n-7, true && i < n && n > 0)
+	fmt.Println(lo < -1 && -1 < hi, 0 < i+1 && i+1 < n)
+	fmt.Println(func() bool {
+		cmp0 := next()
+		return 0 < cmp0 && cmp0 < 2
+	}(), calls)
+	fmt.Println(func() bool {
+		cmp0, cmp1 := next(), next()
+		if !(cmp0 < cmp1) {
+			return false
+		}
+		cmp2 := next()
+		return cmp1 < cmp2
+	}(), calls)
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"go/types"
+	"strconv"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -247,6 +249,17 @@ func Optional(p Parser) ParserFunc {
 	}
 }
 
+// Not succeeds without consuming any input if p fails to match at the current position, and fails otherwise.
+func Not(p Parser) ParserFunc {
+	return func(input Source) (output Source, matched interface{}, err error) {
+		output = input
+		if _, _, e := p.Parse(output); e == nil {
+			err = NewParseError(output.Offset, "unexpected match")
+		}
+		return
+	}
+}
+
 var AnyChar = ParserFunc(func(input Source) (output Source, matched interface{}, err error) {
 	output = input
 	r, size := output.PeekRune()
@@ -286,12 +299,63 @@ func WhitespaceChar() ParserFunc {
 	})
 }
 
+// LineComment matches a semicolon and the rest of the line following it, and returns the text of the comment.
+var LineComment = Recognize(Pair(Rune(';'), ZeroOrMore(Pred(AnyChar, func(matched interface{}) bool {
+	return matched.(rune) != '\n'
+}))))
+
+type blockComment struct{}
+
+func (*blockComment) Parse(input Source) (output Source, matched interface{}, err error) {
+	return Recognize(Sequence(
+		Literal("#|"),
+		ZeroOrMore(Choice(BlockComment, Right(Not(Choice(Literal("#|"), Literal("|#"))), AnyChar))),
+		Literal("|#")))(input)
+}
+
+// BlockComment matches a comment delimited by #| and |#, which may contain other block comments, and returns the
+// text of the comment.
+var BlockComment *blockComment
+
+type datum struct{}
+
+func (*datum) Parse(input Source) (output Source, matched interface{}, err error) {
+	return Choice(
+		Parenthesized(Left(ZeroOrMore(Right(ZeroOrMoreWhitespaceChars(), Datum)), ZeroOrMoreWhitespaceChars())),
+		RuneLit,
+		stringLit(),
+		OneOrMore(Pred(AnyChar, func(matched interface{}) bool {
+			r := matched.(rune)
+			return !unicode.IsSpace(r) && !strings.ContainsRune(`()";`, r)
+		})))(input)
+}
+
+// Datum matches any single form, which is either a balanced parenthesized list of forms, a rune or string literal,
+// or a run of characters up to the next whitespace, parenthesis or comment.
+var Datum *datum
+
+type discard struct{}
+
+func (*discard) Parse(input Source) (output Source, matched interface{}, err error) {
+	return Right(Literal("#_"), Right(ZeroOrMoreWhitespaceChars(), Datum))(input)
+}
+
+// Discard matches #_ followed by a Datum, which is ignored, so that a form can be commented out as a whole.
+var Discard *discard
+
+// trivia matches a single whitespace character, LineComment, BlockComment or Discard.
+func trivia() ParserFunc {
+	return Choice(WhitespaceChar(), LineComment, BlockComment, Discard)
+}
+
+// OneOrMoreWhitespaceChars matches one or more whitespace characters, comments or discarded forms.
 func OneOrMoreWhitespaceChars() ParserFunc {
-	return OneOrMore(WhitespaceChar())
+	return OneOrMore(trivia())
 }
 
+// ZeroOrMoreWhitespaceChars matches zero or more whitespace characters, comments or discarded forms.
 func ZeroOrMoreWhitespaceChars() ParserFunc {
-	return ZeroOrMore(WhitespaceChar())
+	return ZeroOrMore(trivia())
 }
 
 func Map(p Parser, f func(matched interface{}) interface{}) ParserFunc {
@@ -306,22 +370,14 @@ func Map(p Parser, f func(matched interface{}) interface{}) ParserFunc {
 	}
 }
 
+// QuotedString matches an interpreted string literal as defined by the Go spec and returns its content verbatim,
+// without the surrounding double quotes.
 func QuotedString() ParserFunc {
-	return Map(Right(
-		Literal(`"`),
+	return Right(
+		Rune('"'),
 		Left(
-			ZeroOrMore(Pred(AnyChar, func(matched interface{}) bool {
-				return matched.(rune) != '"'
-			})),
-			Literal(`"`))),
-		func(matched interface{}) interface{} {
-			var s strings.Builder
-			for _, r := range matched.([]interface{}) {
-				s.WriteRune(r.(rune))
-			}
-			return s.String()
-		},
-	)
+			Recognize(ZeroOrMore(Choice(escapeSequence('"'), unicodeChar(`"\`)))),
+			Rune('"')))
 }
 
 func Choice(ps ...Parser) ParserFunc {
@@ -345,98 +401,178 @@ func WhitespaceWrap(p Parser) ParserFunc {
 	return Right(ZeroOrMoreWhitespaceChars(), Left(p, ZeroOrMoreWhitespaceChars()))
 }
 
+// Recognize matches p and returns the input consumed by it as a string, in place of the match of p.
+func Recognize(p Parser) ParserFunc {
+	return func(input Source) (output Source, matched interface{}, err error) {
+		output = input
+		r, _, err := p.Parse(output)
+		if err != nil {
+			return
+		}
+		matched = output.Remaining()[:r.Offset-output.Offset]
+		output = r
+		return
+	}
+}
+
+// OneOf matches any one of the characters in chars.
+func OneOf(chars string) ParserFunc {
+	return Pred(AnyChar, func(matched interface{}) bool {
+		return strings.ContainsRune(chars, matched.(rune))
+	})
+}
+
+var (
+	decimalDigit = OneOf("0123456789")
+	binaryDigit  = OneOf("01")
+	octalDigit   = OneOf("01234567")
+	hexDigit     = OneOf("0123456789abcdefABCDEF")
+)
+
+// digits matches one or more digits matched by digit, optionally separated by single underscores.
+func digits(digit Parser) Parser {
+	return Pair(digit, ZeroOrMore(Pair(Optional(Rune('_')), digit)))
+}
+
+var (
+	decimalDigits = digits(decimalDigit)
+	hexDigits     = digits(hexDigit)
+
+	binaryLit = Sequence(Rune('0'), OneOf("bB"), Optional(Rune('_')), digits(binaryDigit))
+	octalLit  = Sequence(Rune('0'), Optional(OneOf("oO")), Optional(Rune('_')), digits(octalDigit))
+	hexLit    = Sequence(Rune('0'), OneOf("xX"), Optional(Rune('_')), hexDigits)
+
+	decimalExponent = Sequence(OneOf("eE"), Optional(OneOf("+-")), decimalDigits)
+	decimalFloat    = Choice(
+		Sequence(decimalDigits, Rune('.'), Optional(decimalDigits), Optional(decimalExponent)),
+		Sequence(decimalDigits, decimalExponent),
+		Sequence(Rune('.'), decimalDigits, Optional(decimalExponent)))
+
+	hexMantissa = Choice(
+		Sequence(Optional(Rune('_')), hexDigits, Rune('.'), Optional(hexDigits)),
+		Sequence(Optional(Rune('_')), hexDigits),
+		Sequence(Rune('.'), hexDigits))
+	hexExponent = Sequence(OneOf("pP"), Optional(OneOf("+-")), decimalDigits)
+	hexFloatLit = Sequence(Rune('0'), OneOf("xX"), hexMantissa, hexExponent)
+)
+
+// newBasicLit returns a function which maps the text of a literal to an *ast.BasicLit of the given kind.
+func newBasicLit(kind token.Token) func(matched interface{}) interface{} {
+	return func(matched interface{}) interface{} {
+		return &ast.BasicLit{
+			Kind:  kind,
+			Value: matched.(string),
+		}
+	}
+}
+
 type _decimalFloatLit struct{}
 
 func (*_decimalFloatLit) Parse(input Source) (output Source, matched interface{}, err error) {
-	return Map(
-		Pair(OneOrMore(decimalDigit), Right(Rune('.'), OneOrMore(decimalDigit))),
-		func(matched interface{}) interface{} {
-			pair := matched.(MatchedPair)
-			var intPart strings.Builder
-			for _, v := range pair.Left.([]interface{}) {
-				intPart.WriteRune(v.(rune))
-			}
-			var fractionalPart strings.Builder
-			for _, v := range pair.Right.([]interface{}) {
-				fractionalPart.WriteRune(v.(rune))
-			}
-			return &ast.BasicLit{
-				Kind:  token.FLOAT,
-				Value: intPart.String() + "." + fractionalPart.String(),
-			}
-		},
-	)(input)
+	return Map(Recognize(decimalFloat), newBasicLit(token.FLOAT))(input)
 }
 
+// decimalFloatLit matches a decimal floating-point literal, such as 1.5, 1e9, 6.02_214e23 or .5.
 var decimalFloatLit *_decimalFloatLit
 
-var decimalDigit = Pred(AnyChar, func(matched interface{}) bool {
-	return unicode.IsDigit(matched.(rune))
-})
+// FloatLit matches a decimal or hexadecimal floating-point literal, such as 1.5e-3 or 0x1p-2, and returns an
+// *ast.BasicLit.
+var FloatLit = Choice(Map(Recognize(hexFloatLit), newBasicLit(token.FLOAT)), decimalFloatLit)
 
+// decimalLit matches a decimal integer literal, such as 0 or 1_000_000.
 func decimalLit() ParserFunc {
-	nonZeroDigit := Pred(decimalDigit, func(matched interface{}) bool {
-		return matched != '0'
-	})
-	nonZeroDecimalLit := Map(Pair(nonZeroDigit, ZeroOrMore(decimalDigit)), func(matched interface{}) interface{} {
-		pair := matched.(MatchedPair)
-		first := pair.Left.(rune)
-		rest := pair.Right.([]interface{})
-		var s strings.Builder
-		s.WriteRune(first)
-		for _, r := range rest {
-			s.WriteRune(r.(rune))
-		}
-		return s.String()
-	})
-	return Map(Choice(Literal("0"), nonZeroDecimalLit), func(matched interface{}) interface{} {
-		return &ast.BasicLit{
-			Kind:  token.INT,
-			Value: matched.(string),
-		}
+	return Map(
+		Recognize(Choice(Pair(OneOf("123456789"), Optional(Pair(Optional(Rune('_')), decimalDigits))), Rune('0'))),
+		newBasicLit(token.INT))
+}
+
+// IntLit matches a binary, octal, hexadecimal or decimal integer literal, such as 0b1010, 0o755, 0755, 0xFF or
+// 1_000, and returns an *ast.BasicLit.
+var IntLit = Choice(Map(Recognize(Choice(binaryLit, octalLit, hexLit)), newBasicLit(token.INT)), decimalLit())
+
+// ImaginaryLit matches an integer, floating-point or decimal digits literal followed by i, such as 1i, 0x10i or
+// 1.5e3i, and returns an *ast.BasicLit.
+var ImaginaryLit = Map(
+	Recognize(Choice(
+		Pair(FloatLit, Rune('i')),
+		Pair(IntLit, Rune('i')),
+		Pair(decimalDigits, Rune('i')))),
+	newBasicLit(token.IMAG))
+
+// escapedChar matches a backslash followed by a character with a special meaning in both rune and string literals.
+var escapedChar = Recognize(Pair(Rune('\\'), OneOf(`abfnrtv\`)))
+
+// unicodeEscape matches a backslash and the given letter followed by n hex digits, such as \u00e9, which must
+// encode a valid Unicode code point.
+func unicodeEscape(letter rune, n int) Parser {
+	hex := make([]Parser, n)
+	for i := range hex {
+		hex[i] = hexDigit
+	}
+	return Pred(Recognize(Sequence(Rune('\\'), Rune(letter), Sequence(hex...))), func(matched interface{}) bool {
+		v, err := strconv.ParseUint(matched.(string)[2:], 16, 32)
+		return err == nil && utf8.ValidRune(rune(v))
 	})
 }
 
-var escapedChar = Choice(
-	Literal(`\a`),
-	Literal(`\b`),
-	Literal(`\f`),
-	Literal(`\n`),
-	Literal(`\r`),
-	Literal(`\t`),
-	Literal(`\v`),
-	Literal(`\\`),
-	Literal(`\'`),
-	Literal(`\"`),
+var (
+	littleUValue   = unicodeEscape('u', 4)
+	bigUValue      = unicodeEscape('U', 8)
+	hexByteValue   = Recognize(Sequence(Rune('\\'), Rune('x'), hexDigit, hexDigit))
+	octalByteValue = Pred(
+		Recognize(Sequence(Rune('\\'), octalDigit, octalDigit, octalDigit)),
+		func(matched interface{}) bool {
+			v, err := strconv.ParseUint(matched.(string)[1:], 8, 32)
+			return err == nil && v <= 255
+		})
 )
 
-var RuneLit = Map(
-	Right(Rune('\''), Left(Choice(escapedChar, AnyChar), Rune('\''))),
-	func(matched interface{}) interface{} {
-		var value string
-		switch v := matched.(type) {
-		case string:
-			value = v
-		case rune:
-			value = string(v)
-		}
-		return &ast.BasicLit{
-			Kind:  token.CHAR,
-			Value: `'` + value + `'`,
-		}
+// escapeSequence matches any escape sequence valid in a literal delimited by quote, which is the only quote
+// character that may be escaped.
+func escapeSequence(quote rune) Parser {
+	return Choice(
+		escapedChar,
+		Recognize(Pair(Rune('\\'), Rune(quote))),
+		littleUValue,
+		bigUValue,
+		hexByteValue,
+		octalByteValue)
+}
+
+// unicodeChar matches any character other than a newline or one of the characters in except.
+func unicodeChar(except string) Parser {
+	return Pred(AnyChar, func(matched interface{}) bool {
+		return matched.(rune) != '\n' && !strings.ContainsRune(except, matched.(rune))
 	})
+}
 
+// RuneLit matches a rune literal, such as 'a', '\n' or '\u00e9', and returns an *ast.BasicLit.
+var RuneLit = Map(
+	Recognize(Sequence(Rune('\''), Choice(escapeSequence('\''), unicodeChar(`'\`)), Rune('\''))),
+	newBasicLit(token.CHAR))
+
+// rawStringLit matches a raw string literal, which may contain any character other than a backquote.
+var rawStringLit = Recognize(Sequence(
+	Rune('`'),
+	ZeroOrMore(Pred(AnyChar, func(matched interface{}) bool {
+		return matched.(rune) != '`'
+	})),
+	Rune('`')))
+
+// stringLit matches an interpreted or raw string literal and returns an *ast.BasicLit.
 func stringLit() ParserFunc {
-	return Map(QuotedString(), func(matched interface{}) interface{} {
-		return &ast.BasicLit{
-			Kind:  token.STRING,
-			Value: `"` + matched.(string) + `"`,
-		}
-	})
+	return Choice(
+		Map(QuotedString(), func(matched interface{}) interface{} {
+			return &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: `"` + matched.(string) + `"`,
+			}
+		}),
+		Map(rawStringLit, newBasicLit(token.STRING)))
 }
 
 func basicLit() ParserFunc {
-	return Choice(decimalFloatLit, decimalLit(), RuneLit, stringLit())
+	return Choice(ImaginaryLit, FloatLit, IntLit, RuneLit, stringLit())
 }
 
 func Rune(r rune) ParserFunc {
@@ -461,10 +597,12 @@ func Rune(r rune) ParserFunc {
 	}
 }
 
+// Parenthesized matches p between parentheses. Whitespace, comments and discarded forms are allowed before the
+// closing parenthesis, so that the last form in a list can be commented out.
 func Parenthesized(p Parser) ParserFunc {
 	return Right(Rune('('),
 		Left(p,
-			Rune(')')),
+			Right(ZeroOrMoreWhitespaceChars(), Rune(')'))),
 	)
 }
 
@@ -478,42 +616,94 @@ func MapConst(p Parser, v interface{}) Parser {
 	})
 }
 
+// BinaryOp matches a binary operator. Each operator is spelled as in Go, except that = may also be used for ==.
+// Longer operators are tried before their prefixes, so that <= is not read as <.
 var BinaryOp = Choice(
+	MapConst(Literal("&&"), token.LAND),
+	MapConst(Literal("||"), token.LOR),
+	MapConst(Literal("&^"), token.AND_NOT),
+	MapConst(Literal("<<"), token.SHL),
+	MapConst(Literal(">>"), token.SHR),
+	MapConst(Literal("=="), token.EQL),
+	MapConst(Literal("!="), token.NEQ),
+	MapConst(Literal("<="), token.LEQ),
+	MapConst(Literal(">="), token.GEQ),
 	MapConst(Rune('+'), token.ADD),
+	MapConst(Rune('-'), token.SUB),
 	MapConst(Rune('*'), token.MUL),
 	MapConst(Rune('/'), token.QUO),
+	MapConst(Rune('%'), token.REM),
+	MapConst(Rune('&'), token.AND),
+	MapConst(Rune('|'), token.OR),
+	MapConst(Rune('^'), token.XOR),
 	MapConst(Rune('='), token.EQL),
 	MapConst(Rune('<'), token.LSS),
 	MapConst(Rune('>'), token.GTR),
-	MapConst(Rune('%'), token.REM),
-	MapConst(Literal("!="), token.NEQ),
 )
 
 type binaryExpr struct{}
 
 func (*binaryExpr) Parse(input Source) (output Source, matched interface{}, err error) {
 	return Map(
-		Parenthesized(Pair(BinaryOp, Right(OneOrMoreWhitespaceChars(), Pair(Expr, Right(OneOrMoreWhitespaceChars(), Expr))))),
+		Pred(
+			Parenthesized(Pair(BinaryOp, OneOrMore(Right(OneOrMoreWhitespaceChars(), Expr)))),
+			func(matched interface{}) bool {
+				pair := matched.(MatchedPair)
+				op := pair.Left.(token.Token)
+				return len(pair.Right.([]interface{})) > 1 || op == token.SUB || op == token.ADD
+			}),
 		func(matched interface{}) interface{} {
 			pair := matched.(MatchedPair)
-			operands := pair.Right.(MatchedPair)
-			return &ast.BinaryExpr{
-				X:  operands.Left.(ast.Expr),
-				Op: pair.Left.(token.Token),
-				Y:  operands.Right.(ast.Expr),
+			op := pair.Left.(token.Token)
+			var operands []ast.Expr
+			for _, operand := range pair.Right.([]interface{}) {
+				operands = append(operands, operand.(ast.Expr))
 			}
+			switch {
+			case len(operands) == 1:
+				return &ast.UnaryExpr{
+					Op: op,
+					X:  operands[0],
+				}
+			case isComparison(op) && len(operands) > 2:
+				return chainComparison(op, operands)
+			}
+			return foldBinaryExpr(op, operands)
 		},
 	)(input)
 }
 
+// BinaryExpr matches a BinaryOp followed by two or more expressions. Operands of arithmetic, bitwise and logical
+// operators are folded from the left, so that (- a b c) returns the *ast.BinaryExpr for a - b - c, while comparisons
+// of more than two operands, such as (< 0 i n), compare each pair of neighbouring operands and join the results
+// with &&. A - or + followed by a single expression, such as (- x), returns an *ast.UnaryExpr.
 var BinaryExpr *binaryExpr
 
+// UnaryOp matches a unary operator, which is written immediately before its operand as in Go, such as -x, !ok, ^mask,
+// &v, *p or <-ch.
 var UnaryOp = Choice(
+	MapConst(Literal("<-"), token.ARROW),
 	MapConst(Rune('&'), token.AND),
+	MapConst(Rune('-'), token.SUB),
+	MapConst(Rune('+'), token.ADD),
+	MapConst(Rune('!'), token.NOT),
+	MapConst(Rune('^'), token.XOR),
+	MapConst(Rune('*'), token.MUL),
 )
 
+// UnaryExpr matches a UnaryOp followed by an expression and returns an *ast.UnaryExpr, or an *ast.StarExpr for a
+// pointer indirection such as *p. Taking the address of a composite literal with an elided type, as in
+// &(lit _ (Name: "y")), returns the literal itself, since Go already implies &T for it within a []*T literal.
 var UnaryExpr = Map(Pair(UnaryOp, Expr), func(matched interface{}) interface{} {
 	pair := matched.(MatchedPair)
+	switch pair.Left.(token.Token) {
+	case token.MUL:
+		return &ast.StarExpr{X: pair.Right.(ast.Expr)}
+	case token.AND:
+		if lit, ok := pair.Right.(*ast.CompositeLit); ok && lit.Type == nil {
+			return lit
+		}
+	}
 	return &ast.UnaryExpr{
 		Op: pair.Left.(token.Token),
 		X:  pair.Right.(ast.Expr),
@@ -523,18 +713,25 @@ var UnaryExpr = Map(Pair(UnaryOp, Expr), func(matched interface{}) interface{} {
 type callExpr struct{}
 
 func (*callExpr) Parse(input Source) (output Source, matched interface{}, err error) {
-	return Map(Parenthesized(Pair(OperandName, ZeroOrMore(Right(OneOrMoreWhitespaceChars(), Expr)))),
+	return Map(Parenthesized(Pair(TypeOrExpr, Pair(
+		ZeroOrMore(Right(OneOrMoreWhitespaceChars(), TypeOrExpr)),
+		Optional(Literal("..."))))),
 		func(matched interface{}) interface{} {
 			pair := matched.(MatchedPair)
 			fun := pair.Left.(ast.Expr)
+			rest := pair.Right.(MatchedPair)
 			var args []ast.Expr
-			for _, basicLit := range pair.Right.([]interface{}) {
+			for _, basicLit := range rest.Left.([]interface{}) {
 				args = append(args, basicLit.(ast.Expr))
 			}
-			return &ast.CallExpr{
+			expr := &ast.CallExpr{
 				Fun:  fun,
 				Args: args,
 			}
+			if rest.Right != nil {
+				expr.Ellipsis = validPos
+			}
+			return expr
 		})(input)
 }
 
@@ -543,28 +740,176 @@ var CallExpr *callExpr
 type expr struct{}
 
 func (*expr) Parse(input Source) (output Source, matched interface{}, err error) {
-	return Choice(basicLit(), BinaryExpr, UnaryExpr, Selector, CallExpr, OperandName)(input)
+	return Choice(basicLit(), BinaryExpr, UnaryExpr, Selector, FuncLit, CompositeLit, IndexExpr, SliceExpr,
+		TypeAssertExpr, CallExpr, Instantiation, OperandName)(input)
 }
 
 var Expr *expr
 
-type selectorCall struct {
-	Sel  *ast.Ident
-	Args []ast.Expr
+// TypeOrExpr matches either a Type or an Expr, for positions such as call arguments which may hold either, as in
+// (make chan int) or ([]byte s).
+var TypeOrExpr = Choice(Type, Expr)
+
+// KeyedElement matches a key followed by a colon and a value, such as (X: 1) or ("a": 1), and returns an
+// *ast.KeyValueExpr.
+var KeyedElement = Map(
+	Parenthesized(Pair(Left(Expr, Rune(':')), Right(OneOrMoreWhitespaceChars(), Expr))),
+	func(matched interface{}) interface{} {
+		pair := matched.(MatchedPair)
+		return &ast.KeyValueExpr{
+			Key:   pair.Left.(ast.Expr),
+			Value: pair.Right.(ast.Expr),
+		}
+	})
+
+type compositeLit struct{}
+
+func (*compositeLit) Parse(input Source) (output Source, matched interface{}, err error) {
+	return Map(
+		Parenthesized(Right(Keyword("lit"), Pair(
+			Right(OneOrMoreWhitespaceChars(), Choice(MapConst(Keyword("_"), nil), Type)),
+			ZeroOrMore(Right(OneOrMoreWhitespaceChars(), Choice(KeyedElement, Expr)))))),
+		func(matched interface{}) interface{} {
+			pair := matched.(MatchedPair)
+			lit := &ast.CompositeLit{}
+			if t, ok := pair.Left.(ast.Expr); ok {
+				lit.Type = t
+			}
+			for _, e := range pair.Right.([]interface{}) {
+				lit.Elts = append(lit.Elts, e.(ast.Expr))
+			}
+			return lit
+		})(input)
 }
 
-var SelectorCall = Map(Parenthesized(Pair(Ident, ZeroOrMore(Right(OneOrMoreWhitespaceChars(), Expr)))), func(matched interface{}) interface{} {
-	match := matched.(MatchedPair)
-	var args []ast.Expr
-	for _, e := range match.Right.([]interface{}) {
-		args = append(args, e.(ast.Expr))
+// CompositeLit matches a lit keyword followed by a type and zero or more elements, each of which is either an
+// expression or a KeyedElement, and returns an *ast.CompositeLit. The type of a composite literal nested within
+// another may be elided by writing _ in its place, such as (lit []Point (lit _ 1 2)) or, for a slice of pointers,
+// (lit []*Point &(lit _ 1 2)).
+var CompositeLit *compositeLit
+
+// newIndexExpr returns an *ast.IndexExpr when there is a single index and an *ast.IndexListExpr otherwise.
+func newIndexExpr(x ast.Expr, matched []interface{}) ast.Expr {
+	var indices []ast.Expr
+	for _, index := range matched {
+		indices = append(indices, index.(ast.Expr))
+	}
+	if len(indices) == 1 {
+		return &ast.IndexExpr{
+			X:     x,
+			Index: indices[0],
+		}
 	}
-	return selectorCall{
-		Sel:  match.Left.(*ast.Ident),
-		Args: args,
+	return &ast.IndexListExpr{
+		X:       x,
+		Indices: indices,
 	}
+}
+
+// TypeArgs matches a bracketed list of one or more types, such as [string int], and returns a []interface{} of the
+// matched types.
+var TypeArgs = Right(Rune('['), Left(OneOrMore(WhitespaceWrap(Type)), Rune(']')))
+
+// Instantiation matches an OperandName followed by TypeArgs, such as Map[int string], and returns an
+// *ast.IndexExpr or *ast.IndexListExpr instantiating a generic function.
+var Instantiation = Map(Pair(OperandName, TypeArgs), func(matched interface{}) interface{} {
+	pair := matched.(MatchedPair)
+	return newIndexExpr(pair.Left.(ast.Expr), pair.Right.([]interface{}))
 })
 
+type indexExpr struct{}
+
+func (*indexExpr) Parse(input Source) (output Source, matched interface{}, err error) {
+	return Map(
+		Parenthesized(Right(Keyword("index"), Pair(
+			Right(OneOrMoreWhitespaceChars(), Expr),
+			OneOrMore(Right(OneOrMoreWhitespaceChars(), TypeOrExpr))))),
+		func(matched interface{}) interface{} {
+			pair := matched.(MatchedPair)
+			return newIndexExpr(pair.Left.(ast.Expr), pair.Right.([]interface{}))
+		})(input)
+}
+
+// IndexExpr matches an index keyword followed by an expression and one or more indices, such as (index xs i),
+// and returns an *ast.IndexExpr. Multiple indices, such as (index Map string int), instantiate a generic function
+// or type and return an *ast.IndexListExpr.
+var IndexExpr *indexExpr
+
+type sliceExpr struct{}
+
+func (*sliceExpr) Parse(input Source) (output Source, matched interface{}, err error) {
+	bound := Right(OneOrMoreWhitespaceChars(), Choice(MapConst(Keyword("_"), nil), Expr))
+	return Map(
+		Parenthesized(Right(Keyword("slice"), Pair(
+			Right(OneOrMoreWhitespaceChars(), Expr),
+			Pred(Sequence(Optional(bound), Optional(bound), Optional(bound)), func(matched interface{}) bool {
+				bounds := matched.([]interface{})
+				_, high := bounds[1].(ast.Expr)
+				_, max := bounds[2].(ast.Expr)
+				return high || !max
+			})))),
+		func(matched interface{}) interface{} {
+			pair := matched.(MatchedPair)
+			bounds := pair.Right.([]interface{})
+			expr := &ast.SliceExpr{X: pair.Left.(ast.Expr)}
+			expr.Low, _ = bounds[0].(ast.Expr)
+			expr.High, _ = bounds[1].(ast.Expr)
+			expr.Max, expr.Slice3 = bounds[2].(ast.Expr)
+			return expr
+		})(input)
+}
+
+// SliceExpr matches a slice keyword followed by an expression and up to three bounds, such as (slice s 1 3) or
+// (slice s 1 3 5), and returns an *ast.SliceExpr. An omitted low or high bound is written as _, such as
+// (slice s _ 3). As in Go, the high bound may only be omitted when there is no max bound.
+var SliceExpr *sliceExpr
+
+type typeAssertExpr struct{}
+
+func (*typeAssertExpr) Parse(input Source) (output Source, matched interface{}, err error) {
+	return Map(
+		Parenthesized(Right(Literal("type-assert"), Pair(
+			Right(OneOrMoreWhitespaceChars(), Expr),
+			Right(OneOrMoreWhitespaceChars(), Type)))),
+		func(matched interface{}) interface{} {
+			pair := matched.(MatchedPair)
+			return &ast.TypeAssertExpr{
+				X:    pair.Left.(ast.Expr),
+				Type: pair.Right.(ast.Expr),
+			}
+		})(input)
+}
+
+// TypeAssertExpr matches a type-assert keyword followed by an expression and a type, such as
+// (type-assert err *PathError), and returns an *ast.TypeAssertExpr. The comma-ok form is written as a define or
+// assign with two names, such as (define (v ok) ((type-assert x int))).
+var TypeAssertExpr *typeAssertExpr
+
+type selectorCall struct {
+	Sel      *ast.Ident
+	Args     []ast.Expr
+	Ellipsis bool
+}
+
+// SelectorCall matches a method name followed by zero or more arguments and an optional ellipsis, such as
+// (Append xs...), inside a Selector.
+var SelectorCall = Map(Parenthesized(Pair(Ident, Pair(
+	ZeroOrMore(Right(OneOrMoreWhitespaceChars(), Expr)),
+	Optional(Literal("..."))))),
+	func(matched interface{}) interface{} {
+		match := matched.(MatchedPair)
+		rest := match.Right.(MatchedPair)
+		var args []ast.Expr
+		for _, e := range rest.Left.([]interface{}) {
+			args = append(args, e.(ast.Expr))
+		}
+		return selectorCall{
+			Sel:      match.Left.(*ast.Ident),
+			Args:     args,
+			Ellipsis: rest.Right != nil,
+		}
+	})
+
 type selector struct{}
 
 func (*selector) Parse(input Source) (output Source, matched interface{}, err error) {
@@ -585,13 +930,17 @@ func (*selector) Parse(input Source) (output Source, matched interface{}, err er
 						Sel: x,
 					}
 				case selectorCall:
-					expr = &ast.CallExpr{
+					call := &ast.CallExpr{
 						Fun: &ast.SelectorExpr{
 							X:   expr,
 							Sel: x.Sel,
 						},
 						Args: x.Args,
 					}
+					if x.Ellipsis {
+						call.Ellipsis = validPos
+					}
+					expr = call
 				}
 			}
 			return expr
@@ -600,56 +949,172 @@ func (*selector) Parse(input Source) (output Source, matched interface{}, err er
 
 var Selector *selector
 
+// newField builds an *ast.Field from the items of a parenthesized struct field, which are zero or more names, a type
+// and an optional string tag. It returns false if the items do not take that form.
+func newField(items []interface{}) (*ast.Field, bool) {
+	field := &ast.Field{}
+	if tag, ok := items[len(items)-1].(*ast.BasicLit); ok && tag.Kind == token.STRING {
+		field.Tag = tag
+		items = items[:len(items)-1]
+	}
+	if len(items) == 0 {
+		return nil, false
+	}
+	for _, item := range items[:len(items)-1] {
+		name, ok := item.(*ast.Ident)
+		if !ok {
+			return nil, false
+		}
+		field.Names = append(field.Names, name)
+	}
+	if _, ok := items[len(items)-1].(*ast.BasicLit); ok {
+		return nil, false
+	}
+	field.Type = items[len(items)-1].(ast.Expr)
+	return field, true
+}
+
+// FieldDecl matches a struct field and returns an *ast.Field. A field is either a parenthesized list of one or more
+// names followed by a type, such as (X Y int), or an embedded type on its own, such as sync.Mutex or *Node. A
+// parenthesized field may end with a string tag, such as (Name string "json:\"name\"") or (*Node "tag").
+var FieldDecl = Choice(
+	Map(
+		Pred(Parenthesized(OneOrMore(WhitespaceWrap(Choice(stringLit(), Type, Ident)))), func(matched interface{}) bool {
+			_, ok := newField(matched.([]interface{}))
+			return ok
+		}),
+		func(matched interface{}) interface{} {
+			field, _ := newField(matched.([]interface{}))
+			return field
+		}),
+	Map(Type, func(matched interface{}) interface{} {
+		return &ast.Field{Type: matched.(ast.Expr)}
+	}))
+
 type structType struct{}
 
 func (*structType) Parse(input Source) (output Source, matched interface{}, err error) {
 	return Map(Parenthesized(
 		Right(
-			Literal(token.STRUCT.String()),
+			Keyword(token.STRUCT.String()),
 			ZeroOrMore(
 				Right(
-					ZeroOrMoreWhitespaceChars(),
-					Parenthesized(
-						Pair(Ident, Right(OneOrMoreWhitespaceChars(), Ident))))))),
+					OneOrMoreWhitespaceChars(),
+					FieldDecl)))),
 		func(matched interface{}) interface{} {
 			matches := matched.([]interface{})
 			var fields []*ast.Field
 			for _, m := range matches {
-				pair := m.(MatchedPair)
-				fields = append(fields, &ast.Field{
-					Names: []*ast.Ident{pair.Left.(*ast.Ident)},
-					Type:  pair.Right.(*ast.Ident),
-				})
+				fields = append(fields, m.(*ast.Field))
 			}
 			return &ast.StructType{
-				Fields: &ast.FieldList{
-					List: fields,
-				},
+				Fields: newMemberList(fields),
 			}
 		},
 	)(input)
 }
 
+// StructType matches a struct keyword followed by zero or more FieldDecls and returns an *ast.StructType.
 var StructType *structType
 
+// newMemberList returns the field list of a struct or interface type. An empty list is given valid braces on the same
+// line, so that it is printed as struct{} or interface{} rather than with its braces on separate lines.
+func newMemberList(fields []*ast.Field) *ast.FieldList {
+	list := &ast.FieldList{List: fields}
+	if len(fields) == 0 {
+		list.Opening = validPos
+		list.Closing = validPos
+	}
+	return list
+}
+
+// TildeType matches a type prefixed with a tilde, denoting all types with that underlying type, and returns an
+// *ast.UnaryExpr.
+var TildeType = Map(Right(Rune('~'), Type), func(matched interface{}) interface{} {
+	return &ast.UnaryExpr{
+		Op: token.TILDE,
+		X:  matched.(ast.Expr),
+	}
+})
+
+// UnionType matches a pipe followed by one or more types or TildeTypes, such as (| ~int ~float64), and returns a
+// left-associative *ast.BinaryExpr joining them with token.OR.
+var UnionType = Map(
+	Parenthesized(Right(Rune('|'), OneOrMore(Right(OneOrMoreWhitespaceChars(), Choice(TildeType, Type))))),
+	func(matched interface{}) interface{} {
+		terms := matched.([]interface{})
+		union := terms[0].(ast.Expr)
+		for _, term := range terms[1:] {
+			union = &ast.BinaryExpr{
+				X:  union,
+				Op: token.OR,
+				Y:  term.(ast.Expr),
+			}
+		}
+		return union
+	})
+
+// MethodSpec matches a method name followed by a Signature, such as (Area () (results float64)), and returns an
+// *ast.Field.
+var MethodSpec = Map(
+	Parenthesized(Pair(Ident, Right(OneOrMoreWhitespaceChars(), Signature))),
+	func(matched interface{}) interface{} {
+		pair := matched.(MatchedPair)
+		return &ast.Field{
+			Names: []*ast.Ident{pair.Left.(*ast.Ident)},
+			Type:  pair.Right.(*ast.FuncType),
+		}
+	})
+
+type interfaceType struct{}
+
+func (*interfaceType) Parse(input Source) (output Source, matched interface{}, err error) {
+	return Map(Parenthesized(
+		Right(
+			Keyword(token.INTERFACE.String()),
+			ZeroOrMore(WhitespaceWrap(Choice(MethodSpec, UnionType, TildeType, Type))))),
+		func(matched interface{}) interface{} {
+			var fields []*ast.Field
+			for _, m := range matched.([]interface{}) {
+				switch v := m.(type) {
+				case *ast.Field:
+					fields = append(fields, v)
+				case ast.Expr:
+					fields = append(fields, &ast.Field{Type: v})
+				}
+			}
+			return &ast.InterfaceType{
+				Methods: newMemberList(fields),
+			}
+		},
+	)(input)
+}
+
+// InterfaceType matches an interface keyword followed by zero or more MethodSpecs, embedded types and UnionTypes
+// and returns an *ast.InterfaceType.
+var InterfaceType *interfaceType
+
 type typeDecl struct{}
 
 func (*typeDecl) Parse(input Source) (output Source, matched interface{}, err error) {
 	return Map(Parenthesized(Right(
 		Literal(token.TYPE.String()), Right(OneOrMoreWhitespaceChars(),
-			Pair(Ident, Right(OneOrMoreWhitespaceChars(),
-				StructType))))),
+			Pair(Ident, Sequence(optionalTypeParameters, optionalDocString, Right(OneOrMoreWhitespaceChars(),
+				Type)))))),
 		func(matched interface{}) interface{} {
 			pair := matched.(MatchedPair)
-			return &ast.GenDecl{
-				Tok: token.TYPE,
-				Specs: []ast.Spec{
-					&ast.TypeSpec{
-						Name: pair.Left.(*ast.Ident),
-						Type: pair.Right.(*ast.StructType),
-					},
-				},
+			rest := pair.Right.([]interface{})
+			spec := &ast.TypeSpec{
+				Name: pair.Left.(*ast.Ident),
+				Type: rest[2].(ast.Expr),
+			}
+			spec.TypeParams, _ = rest[0].(*ast.FieldList)
+			decl := &ast.GenDecl{
+				Tok:   token.TYPE,
+				Specs: []ast.Spec{spec},
 			}
+			decl.Doc, _ = rest[1].(*ast.CommentGroup)
+			return decl
 		})(input)
 }
 
@@ -678,14 +1143,64 @@ func (*statementList) Parse(input Source) (output Source, matched interface{}, e
 
 var StatementList *statementList
 
-var Statement = Choice(ExprSwitchStmt, ForStmt, DeclStmt, IfStmt, SimpleStmt)
+var Statement = Choice(ExprSwitchStmt, TypeSwitchStmt, SelectStmt, ForStmt, RangeStmt, DeclStmt, IfStmt, ReturnStmt,
+	GoStmt, DeferStmt, BranchStmt, LabeledStmt, SimpleStmt)
+
+var SimpleStmt = Choice(InitStmt, ExprStmt)
 
-var SimpleStmt = Choice(Define, Assignment, IncDecStmt, ExprStmt)
+// InitStmt matches the simple statements other than expression statements, which can appear before the tag of a
+// switch statement without being mistaken for it.
+var InitStmt = Choice(Define, Assignment, CompoundAssignment, IncDecStmt, SendStmt)
 
 var ExprStmt = Map(Expr, func(matched interface{}) interface{} {
 	return &ast.ExprStmt{X: matched.(ast.Expr)}
 })
 
+// ReturnStmt matches a return keyword followed by zero or more expressions and returns an *ast.ReturnStmt.
+var ReturnStmt = Map(
+	Parenthesized(Right(Keyword(token.RETURN.String()), ZeroOrMore(Right(OneOrMoreWhitespaceChars(), Expr)))),
+	func(matched interface{}) interface{} {
+		var results []ast.Expr
+		for _, e := range matched.([]interface{}) {
+			results = append(results, e.(ast.Expr))
+		}
+		return &ast.ReturnStmt{Results: results}
+	})
+
+// CallStmtExpr matches an Expr which must be a function call, as required by go and defer statements.
+var CallStmtExpr = Pred(Expr, func(matched interface{}) bool {
+	_, ok := matched.(*ast.CallExpr)
+	return ok
+})
+
+// GoStmt matches a go keyword followed by a function call and returns an *ast.GoStmt.
+var GoStmt = Map(
+	Parenthesized(Right(Keyword(token.GO.String()), Right(OneOrMoreWhitespaceChars(), CallStmtExpr))),
+	func(matched interface{}) interface{} {
+		return &ast.GoStmt{Call: matched.(*ast.CallExpr)}
+	})
+
+// DeferStmt matches a defer keyword followed by a function call and returns an *ast.DeferStmt.
+var DeferStmt = Map(
+	Parenthesized(Right(Keyword(token.DEFER.String()), Right(OneOrMoreWhitespaceChars(), CallStmtExpr))),
+	func(matched interface{}) interface{} {
+		return &ast.DeferStmt{Call: matched.(*ast.CallExpr)}
+	})
+
+var sendOperands = Pair(Right(OneOrMoreWhitespaceChars(), Expr), Right(OneOrMoreWhitespaceChars(), Expr))
+
+// SendStmt matches a send keyword followed by a channel and a value, such as (send ch v), and returns an
+// *ast.SendStmt.
+var SendStmt = Map(
+	Parenthesized(Right(Keyword("send"), sendOperands)),
+	func(matched interface{}) interface{} {
+		pair := matched.(MatchedPair)
+		return &ast.SendStmt{
+			Chan:  pair.Left.(ast.Expr),
+			Value: pair.Right.(ast.Expr),
+		}
+	})
+
 var IncDecStmt = Map(
 	Parenthesized(Pair(Choice(MapConst(Keyword("inc"), token.INC), MapConst(Keyword("dec"), token.DEC)), WhitespaceWrap(Expr))),
 	func(matched interface{}) interface{} {
@@ -712,23 +1227,293 @@ func Noop() ParserFunc {
 	}
 }
 
+type _type struct{}
+
+func (*_type) Parse(input Source) (output Source, matched interface{}, err error) {
+	return Choice(PointerType, ArrayType, MapType, ChanType, FuncType, StructType, InterfaceType, GenericType,
+		QualifiedIdent, TypeName)(input)
+}
+
+// reserved holds the keywords introduced by Jo forms, which like Go keywords cannot be used as type names.
+var reserved = map[string]bool{
+	"assign":  true,
+	"dec":     true,
+	"define":  true,
+	"do":      true,
+	"elif":    true,
+	"fn":      true,
+	"inc":     true,
+	"index":   true,
+	"label":   true,
+	"lit":     true,
+	"recv":    true,
+	"method":  true,
+	"results": true,
+	"sel":     true,
+	"send":    true,
+	"slice":   true,
+}
+
+// TypeName matches an identifier which is neither a Go keyword nor a Jo keyword and returns an *ast.Ident.
+var TypeName = Pred(Ident, func(matched interface{}) bool {
+	name := matched.(*ast.Ident).Name
+	return !token.IsKeyword(name) && !reserved[name]
+})
+
+// Type matches a type name, a qualified type name such as time.Time, or a type literal and returns an ast.Expr.
+//
+// Pointer, slice, array, map and channel types are spelled as in Go, such as *T, []byte, [4]int, map[string]int,
+// chan T, chan<- T and <-chan T, while function, struct and interface types are written as S-expressions, such as
+// (func ((x int)) (error)), (struct (X int)) and (interface (String () (string))).
+var Type *_type
+
+// PointerType matches a type prefixed with an asterisk and returns an *ast.StarExpr.
+var PointerType = Map(Right(Rune('*'), Type), func(matched interface{}) interface{} {
+	return &ast.StarExpr{X: matched.(ast.Expr)}
+})
+
+// VariadicType matches a type prefixed with an ellipsis and returns an *ast.Ellipsis.
+var VariadicType = Map(Right(Literal("..."), Type), func(matched interface{}) interface{} {
+	return &ast.Ellipsis{Elt: matched.(ast.Expr)}
+})
+
+// GenericType matches a type name followed by TypeArgs, such as List[int] or maps.Map[string int], and returns an
+// *ast.IndexExpr or *ast.IndexListExpr instantiating a generic type.
+var GenericType = Map(Pair(Choice(QualifiedIdent, TypeName), TypeArgs), func(matched interface{}) interface{} {
+	pair := matched.(MatchedPair)
+	return newIndexExpr(pair.Left.(ast.Expr), pair.Right.([]interface{}))
+})
+
+// ArrayType matches a slice type such as []T or an array type such as [4]T or [...]T and returns an *ast.ArrayType.
+var ArrayType = Map(
+	Pair(Right(Rune('['), Left(Optional(Choice(Literal("..."), Expr)), Rune(']'))), Type),
+	func(matched interface{}) interface{} {
+		pair := matched.(MatchedPair)
+		var length ast.Expr
+		switch v := pair.Left.(type) {
+		case string:
+			length = &ast.Ellipsis{}
+		case ast.Expr:
+			length = v
+		}
+		return &ast.ArrayType{
+			Len: length,
+			Elt: pair.Right.(ast.Expr),
+		}
+	})
+
+// MapType matches a map type such as map[string]int and returns an *ast.MapType.
+var MapType = Map(
+	Pair(Right(Literal("map["), Left(Type, Rune(']'))), Type),
+	func(matched interface{}) interface{} {
+		pair := matched.(MatchedPair)
+		return &ast.MapType{
+			Key:   pair.Left.(ast.Expr),
+			Value: pair.Right.(ast.Expr),
+		}
+	})
+
+// ChanType matches a bidirectional, send-only or receive-only channel type such as chan T, chan<- T or <-chan T and
+// returns an *ast.ChanType.
+var ChanType = Map(
+	Choice(
+		Pair(MapConst(Literal("chan<-"), ast.SEND), Right(ZeroOrMoreWhitespaceChars(), Type)),
+		Pair(MapConst(Literal("<-chan"), ast.RECV), Right(OneOrMoreWhitespaceChars(), Type)),
+		Pair(MapConst(Keyword(token.CHAN.String()), ast.SEND|ast.RECV), Right(OneOrMoreWhitespaceChars(), Type))),
+	func(matched interface{}) interface{} {
+		pair := matched.(MatchedPair)
+		return &ast.ChanType{
+			Dir:   pair.Left.(ast.ChanDir),
+			Value: pair.Right.(ast.Expr),
+		}
+	})
+
+// Signature matches a ParameterList followed by an optional ResultList, such as () (results int), and returns an
+// *ast.FuncType. Since a signature has no body, its results may also be written as a bare ParameterList, as in () (int).
+var Signature = Map(
+	Pair(ParameterList, Optional(Right(OneOrMoreWhitespaceChars(), Choice(ResultList, ParameterList)))),
+	func(matched interface{}) interface{} {
+		pair := matched.(MatchedPair)
+		funcType := &ast.FuncType{Params: pair.Left.(*ast.FieldList)}
+		if results, ok := pair.Right.(*ast.FieldList); ok && len(results.List) > 0 {
+			funcType.Results = results
+		}
+		return funcType
+	})
+
+// FuncType matches a func keyword followed by a Signature, such as (func (int) (results error)), and returns an
+// *ast.FuncType.
+var FuncType = Parenthesized(Right(
+	Keyword(token.FUNC.String()), Right(OneOrMoreWhitespaceChars(),
+		Signature)))
+
+// ParameterDecl matches either a bare type or a parenthesized list of one or more identifiers followed by a type,
+// such as (a b int), and returns an *ast.Field. The type of the final parameter may be a VariadicType.
+var ParameterDecl = Map(
+	Choice(
+		Parenthesized(Pair(OneOrMore(Left(Ident, OneOrMoreWhitespaceChars())), Choice(VariadicType, Type))),
+		VariadicType,
+		Type),
+	func(matched interface{}) interface{} {
+		pair, ok := matched.(MatchedPair)
+		if !ok {
+			return &ast.Field{Type: matched.(ast.Expr)}
+		}
+		var names []*ast.Ident
+		for _, name := range pair.Left.([]interface{}) {
+			names = append(names, name.(*ast.Ident))
+		}
+		return &ast.Field{
+			Names: names,
+			Type:  pair.Right.(ast.Expr),
+		}
+	})
+
+// TypeParamDecl matches a parenthesized list of one or more identifiers followed by a constraint, such as (T any),
+// (K V comparable) or (N (| ~int ~float64)), and returns an *ast.Field.
+var TypeParamDecl = Map(
+	Parenthesized(Pair(OneOrMore(Left(Ident, OneOrMoreWhitespaceChars())), Choice(UnionType, TildeType, Type))),
+	func(matched interface{}) interface{} {
+		pair := matched.(MatchedPair)
+		var names []*ast.Ident
+		for _, name := range pair.Left.([]interface{}) {
+			names = append(names, name.(*ast.Ident))
+		}
+		return &ast.Field{
+			Names: names,
+			Type:  pair.Right.(ast.Expr),
+		}
+	})
+
+// TypeParameters matches a bracketed list of one or more TypeParamDecl, such as [(K comparable) (V any)], and returns
+// an *ast.FieldList.
+var TypeParameters = Map(
+	Right(Rune('['), Left(OneOrMore(WhitespaceWrap(TypeParamDecl)), Rune(']'))),
+	func(matched interface{}) interface{} {
+		var fields []*ast.Field
+		for _, field := range matched.([]interface{}) {
+			fields = append(fields, field.(*ast.Field))
+		}
+		return &ast.FieldList{List: fields}
+	})
+
+// optionalTypeParameters matches optional TypeParameters following the name of a declaration, which may be separated
+// from the name by whitespace, as in (type List [(T any)] ...).
+var optionalTypeParameters = Optional(Right(ZeroOrMoreWhitespaceChars(), TypeParameters))
+
+// ParameterList matches a parenthesized list of ParameterDecl and returns an *ast.FieldList.
+var ParameterList = Map(
+	Parenthesized(ZeroOrMore(WhitespaceWrap(ParameterDecl))),
+	func(matched interface{}) interface{} {
+		var fields []*ast.Field
+		for _, field := range matched.([]interface{}) {
+			fields = append(fields, field.(*ast.Field))
+		}
+		return &ast.FieldList{List: fields}
+	})
+
+// ResultList matches a results keyword followed by zero or more ParameterDecl, such as (results int) or
+// (results (q int) (err error)), and returns an *ast.FieldList.
+var ResultList = Map(
+	Parenthesized(Right(Keyword("results"), ZeroOrMore(Right(OneOrMoreWhitespaceChars(), ParameterDecl)))),
+	func(matched interface{}) interface{} {
+		var fields []*ast.Field
+		for _, field := range matched.([]interface{}) {
+			fields = append(fields, field.(*ast.Field))
+		}
+		return &ast.FieldList{List: fields}
+	})
+
+// bareResultList matches a parenthesized list of predeclared type names, such as (int) or (int error), which is a
+// result list written without the results keyword rather than a call statement.
+var bareResultList = Parenthesized(OneOrMore(WhitespaceWrap(Pred(Ident, func(matched interface{}) bool {
+	_, ok := types.Universe.Lookup(matched.(*ast.Ident).Name).(*types.TypeName)
+	return ok
+}))))
+
+// FunctionBody matches a ParameterList, an optional ResultList and a StatementList and returns an *ast.FuncLit
+// holding the function type and body. A bare result list such as (int) in place of the ResultList is rejected.
+var FunctionBody = Map(
+	Sequence(
+		ParameterList,
+		Optional(Right(OneOrMoreWhitespaceChars(), ResultList)),
+		WhitespaceWrap(Right(Not(bareResultList), StatementList))),
+	func(matched interface{}) interface{} {
+		seq := matched.([]interface{})
+		funcType := &ast.FuncType{Params: seq[0].(*ast.FieldList)}
+		if results, ok := seq[1].(*ast.FieldList); ok && len(results.List) > 0 {
+			funcType.Results = results
+		}
+		return &ast.FuncLit{
+			Type: funcType,
+			Body: &ast.BlockStmt{
+				List: seq[2].([]ast.Stmt),
+			},
+		}
+	})
+
+// DocString matches a string literal documenting a declaration and returns an *ast.CommentGroup holding its text as
+// Go line comments.
+var DocString = Map(stringLit(), func(matched interface{}) interface{} {
+	text, _ := strconv.Unquote(matched.(*ast.BasicLit).Value)
+	return &ast.CommentGroup{List: commentLines(text)}
+})
+
+// optionalDocString matches an optional DocString preceded by whitespace.
+var optionalDocString = Optional(Right(OneOrMoreWhitespaceChars(), DocString))
+
+// FunctionDecl matches a func keyword followed by a function name, optional TypeParameters, an optional DocString and
+// a FunctionBody, such as (func double "double returns twice x." ((x int)) (results int) (return (* 2 x))), and
+// returns an *ast.FuncDecl.
 var FunctionDecl = Map(Parenthesized(Right(
 	Literal(token.FUNC.String()), Right(OneOrMoreWhitespaceChars(), Pair(
-		Ident, Right(Right(OneOrMoreWhitespaceChars(), Parenthesized(Noop())), WhitespaceWrap(
-			StatementList)))))),
+		Ident, Sequence(optionalTypeParameters, optionalDocString, Right(OneOrMoreWhitespaceChars(),
+			FunctionBody)))))),
 	func(matched interface{}) interface{} {
 		pair := matched.(MatchedPair)
-		return &ast.FuncDecl{
+		rest := pair.Right.([]interface{})
+		fn := rest[2].(*ast.FuncLit)
+		fn.Type.TypeParams, _ = rest[0].(*ast.FieldList)
+		decl := &ast.FuncDecl{
 			Name: pair.Left.(*ast.Ident),
-			Type: &ast.FuncType{Params: &ast.FieldList{}},
-			Body: &ast.BlockStmt{
-				List: pair.Right.([]ast.Stmt),
-			},
+			Type: fn.Type,
+			Body: fn.Body,
 		}
+		decl.Doc, _ = rest[1].(*ast.CommentGroup)
+		return decl
 	},
 )
 
-var TopLevelDecl = Choice(TypeDecl, FunctionDecl)
+// FuncLit matches an fn keyword followed by a FunctionBody, such as (fn ((x int)) (results int) ...), and returns
+// an *ast.FuncLit.
+var FuncLit = Parenthesized(Right(
+	Keyword("fn"), Right(OneOrMoreWhitespaceChars(),
+		FunctionBody)))
+
+// MethodDecl matches a method keyword followed by a receiver, a method name, an optional DocString and a
+// FunctionBody, such as (method (p *Point) Dist () (results float64) ...), and returns an *ast.FuncDecl.
+var MethodDecl = Map(Parenthesized(Right(
+	Keyword("method"), Right(OneOrMoreWhitespaceChars(), Pair(
+		ParameterDecl, Right(OneOrMoreWhitespaceChars(), Sequence(
+			Ident, optionalDocString, Right(OneOrMoreWhitespaceChars(),
+				FunctionBody))))))),
+	func(matched interface{}) interface{} {
+		pair := matched.(MatchedPair)
+		method := pair.Right.([]interface{})
+		fn := method[2].(*ast.FuncLit)
+		decl := &ast.FuncDecl{
+			Recv: &ast.FieldList{
+				List: []*ast.Field{pair.Left.(*ast.Field)},
+			},
+			Name: method[0].(*ast.Ident),
+			Type: fn.Type,
+			Body: fn.Body,
+		}
+		decl.Doc, _ = method[1].(*ast.CommentGroup)
+		return decl
+	})
+
+var TopLevelDecl = Choice(ConstDecl, VarDecl, TypeDecl, FunctionDecl, MethodDecl)
 
 var ImportDecl = Map(
 	Parenthesized(Right(Literal(token.IMPORT.String()), OneOrMore(Right(OneOrMoreWhitespaceChars(), stringLit())))),
@@ -781,23 +1566,40 @@ func (*block) Parse(input Source) (output Source, matched interface{}, err error
 // Block matches either a do expression or a single statement and returns a pointer to an ast.BlockStmt.
 var Block *block
 
+// ifClause matches an optional InitStmt, a condition and a Block, and returns an *ast.IfStmt without an else branch.
+var ifClause = Map(
+	Sequence(Optional(WhitespaceWrap(InitStmt)), WhitespaceWrap(Expr), WhitespaceWrap(Block)),
+	func(matched interface{}) interface{} {
+		seq := matched.([]interface{})
+		stmt := &ast.IfStmt{
+			Cond: seq[1].(ast.Expr),
+			Body: seq[2].(*ast.BlockStmt),
+		}
+		stmt.Init, _ = seq[0].(ast.Stmt)
+		return stmt
+	})
+
+// IfStmt matches an if keyword followed by an optional InitStmt, a condition and a Block, then zero or more elif
+// arms such as (elif (= x 1) ...) taking the same form, and finally an optional else Block. It returns an
+// *ast.IfStmt whose else-if arms form a flat chain, such as
+// (if (define err ((f))) (!= err nil) (return err) (elif (> x 0) (g)) (h)).
 var IfStmt = Map(Parenthesized(Right(
-	Keyword(token.IF.String()), Pair(Right(ZeroOrMoreWhitespaceChars(),
-		Expr), Pair(
-		WhitespaceWrap(Block),
-		Optional(WhitespaceWrap(Block)))))),
+	Keyword(token.IF.String()), Sequence(
+		ifClause,
+		ZeroOrMore(WhitespaceWrap(Parenthesized(Right(Keyword("elif"), ifClause)))),
+		Optional(WhitespaceWrap(Block))))),
 	func(matched interface{}) interface{} {
-		pair := matched.(MatchedPair)
-		cond, arms := pair.Left.(ast.Expr), pair.Right.(MatchedPair)
-		var Else ast.Stmt
-		if e, ok := arms.Right.(*ast.BlockStmt); ok {
-			Else = e
+		seq := matched.([]interface{})
+		stmt := seq[0].(*ast.IfStmt)
+		last := stmt
+		for _, arm := range seq[1].([]interface{}) {
+			last.Else = arm.(*ast.IfStmt)
+			last = arm.(*ast.IfStmt)
 		}
-		return &ast.IfStmt{
-			Cond: cond,
-			Body: arms.Left.(*ast.BlockStmt),
-			Else: Else,
+		if e, ok := seq[2].(*ast.BlockStmt); ok {
+			last.Else = e
 		}
+		return stmt
 	})
 
 var IdentifierList = Map(Choice(Parenthesized(OneOrMore(WhitespaceWrap(Ident))), Ident), func(matched interface{}) interface{} {
@@ -814,22 +1616,24 @@ var IdentifierList = Map(Choice(Parenthesized(OneOrMore(WhitespaceWrap(Ident))),
 	return nil
 })
 
-// ExpressionList matches a list of parenthesized expressions, a single identifier or a single basic literal and returns a slice of ast.Expr.
-var ExpressionList = Map(Choice(Parenthesized(OneOrMore(WhitespaceWrap(Expr))), Ident, basicLit()), func(matched interface{}) interface{} {
-	switch v := matched.(type) {
-	case []interface{}:
-		exprs := make([]ast.Expr, len(v))
-		for i, match := range v {
-			exprs[i] = match.(ast.Expr)
+// ExpressionList matches either a parenthesized list of expressions or a single expression which is not itself
+// parenthesized, such as a name, a basic literal or a unary expression like *p, and returns a slice of ast.Expr. A
+// single parenthesized expression must be wrapped in a list of its own, as in ((f x)).
+var ExpressionList = Map(
+	Choice(Parenthesized(OneOrMore(WhitespaceWrap(Expr))), basicLit(), UnaryExpr, Instantiation, OperandName),
+	func(matched interface{}) interface{} {
+		switch v := matched.(type) {
+		case []interface{}:
+			exprs := make([]ast.Expr, len(v))
+			for i, match := range v {
+				exprs[i] = match.(ast.Expr)
+			}
+			return exprs
+		case ast.Expr:
+			return []ast.Expr{v}
 		}
-		return exprs
-	case *ast.Ident:
-		return []ast.Expr{v}
-	case *ast.BasicLit:
-		return []ast.Expr{v}
-	}
-	return nil
-})
+		return nil
+	})
 
 var Define = Map(Parenthesized(Right(
 	Literal("define"), Pair(Right(OneOrMoreWhitespaceChars(),
@@ -851,43 +1655,213 @@ func Keyword(k string) Parser {
 	})
 }
 
-var DeclStmt = Map(
-	Parenthesized(Right(Keyword("var"), Pair(WhitespaceWrap(Ident), WhitespaceWrap(Ident)))),
+// ValueSpec matches one or more names followed by an optional type and an optional = and ExpressionList of values,
+// such as x int, Pi = 3.14 or (a b) int = (1 2), and returns an *ast.ValueSpec.
+var ValueSpec = Map(
+	Sequence(
+		IdentifierList,
+		Optional(Right(OneOrMoreWhitespaceChars(), Type)),
+		Optional(Right(OneOrMoreWhitespaceChars(), Right(Rune('='), Right(OneOrMoreWhitespaceChars(), ExpressionList))))),
 	func(matched interface{}) interface{} {
-		pair := matched.(MatchedPair)
-		return &ast.DeclStmt{
-			Decl: &ast.GenDecl{
-				Tok: token.VAR,
-				Specs: []ast.Spec{
-					&ast.ValueSpec{
-						Names: []*ast.Ident{pair.Left.(*ast.Ident)},
-						Type:  pair.Right.(*ast.Ident),
-					},
-				},
-			},
+		seq := matched.([]interface{})
+		spec := &ast.ValueSpec{}
+		for _, name := range seq[0].([]ast.Expr) {
+			spec.Names = append(spec.Names, name.(*ast.Ident))
 		}
+		spec.Type, _ = seq[1].(ast.Expr)
+		spec.Values, _ = seq[2].([]ast.Expr)
+		return spec
 	})
 
-var ForStmt = Map(
-	Parenthesized(Right(Keyword("for"), Sequence(
-		WhitespaceWrap(SimpleStmt),
+// genDecl returns a parser matching a keyword and an optional DocString followed by either a single ValueSpec, such
+// as (var x int), or a group of parenthesised ValueSpecs, such as (const (Red = iota) (Green) (Blue)), which returns
+// an *ast.GenDecl with the given token. The grouped form is tried first, so (var (x int)) declares x rather than the
+// two variables x and int.
+func genDecl(tok token.Token) Parser {
+	closing := Right(ZeroOrMoreWhitespaceChars(), Rune(')'))
+	return Map(
+		Right(Rune('('), Right(Keyword(tok.String()), Pair(optionalDocString, Choice(
+			Left(OneOrMore(Right(OneOrMoreWhitespaceChars(), Parenthesized(ValueSpec))), closing),
+			Left(Right(OneOrMoreWhitespaceChars(), ValueSpec), closing))))),
+		func(matched interface{}) interface{} {
+			pair := matched.(MatchedPair)
+			decl := &ast.GenDecl{Tok: tok}
+			decl.Doc, _ = pair.Left.(*ast.CommentGroup)
+			switch v := pair.Right.(type) {
+			case []interface{}:
+				decl.Lparen = validPos
+				for _, spec := range v {
+					decl.Specs = append(decl.Specs, spec.(ast.Spec))
+				}
+			case ast.Spec:
+				decl.Specs = []ast.Spec{v}
+			}
+			return decl
+		})
+}
+
+// ConstDecl matches a const declaration and returns an *ast.GenDecl.
+var ConstDecl = genDecl(token.CONST)
+
+// VarDecl matches a var declaration and returns an *ast.GenDecl.
+var VarDecl = genDecl(token.VAR)
+
+// DeclStmt matches a ConstDecl or VarDecl inside a function body and returns an *ast.DeclStmt. The DocString of a
+// declaration inside a function body is dropped, like any other comment there.
+var DeclStmt = Map(Choice(ConstDecl, VarDecl), func(matched interface{}) interface{} {
+	decl := matched.(*ast.GenDecl)
+	decl.Doc = nil
+	return &ast.DeclStmt{Decl: decl}
+})
+
+// emptyClause matches an empty pair of parentheses standing in for an omitted clause and returns nil.
+var emptyClause = MapConst(Parenthesized(ZeroOrMoreWhitespaceChars()), nil)
+
+// ForStmt matches a for keyword followed by either an init statement, a condition, a post statement and a Block,
+// a condition and a Block, or only a Block, and returns an *ast.ForStmt. Any of the init statement, condition and
+// post statement in the first form may be omitted by writing () in its place.
+var ForStmt = Parenthesized(Right(Keyword("for"), Choice(
+	Map(
+		Sequence(
+			WhitespaceWrap(Choice(emptyClause, SimpleStmt)),
+			WhitespaceWrap(Choice(emptyClause, Expr)),
+			WhitespaceWrap(Choice(emptyClause, SimpleStmt)),
+			WhitespaceWrap(Block)),
+		func(matched interface{}) interface{} {
+			seq := matched.([]interface{})
+			stmt := &ast.ForStmt{Body: seq[3].(*ast.BlockStmt)}
+			stmt.Init, _ = seq[0].(ast.Stmt)
+			stmt.Cond, _ = seq[1].(ast.Expr)
+			stmt.Post, _ = seq[2].(ast.Stmt)
+			return stmt
+		}),
+	Map(
+		Pair(WhitespaceWrap(Expr), WhitespaceWrap(Block)),
+		func(matched interface{}) interface{} {
+			pair := matched.(MatchedPair)
+			return &ast.ForStmt{
+				Cond: pair.Left.(ast.Expr),
+				Body: pair.Right.(*ast.BlockStmt),
+			}
+		}),
+	Map(
+		WhitespaceWrap(Block),
+		func(matched interface{}) interface{} {
+			return &ast.ForStmt{Body: matched.(*ast.BlockStmt)}
+		}))))
+
+// BranchStmt matches a break or continue keyword followed by an optional label, or a fallthrough keyword on its own,
+// and returns an *ast.BranchStmt.
+var BranchStmt = Choice(
+	Map(
+		Parenthesized(Pair(
+			Choice(
+				MapConst(Keyword(token.BREAK.String()), token.BREAK),
+				MapConst(Keyword(token.CONTINUE.String()), token.CONTINUE)),
+			Optional(Right(OneOrMoreWhitespaceChars(), Ident)))),
+		func(matched interface{}) interface{} {
+			pair := matched.(MatchedPair)
+			stmt := &ast.BranchStmt{Tok: pair.Left.(token.Token)}
+			stmt.Label, _ = pair.Right.(*ast.Ident)
+			return stmt
+		}),
+	Map(
+		Parenthesized(Keyword(token.FALLTHROUGH.String())),
+		func(matched interface{}) interface{} {
+			return &ast.BranchStmt{Tok: token.FALLTHROUGH}
+		}))
+
+type labeledStmt struct{}
+
+func (*labeledStmt) Parse(input Source) (output Source, matched interface{}, err error) {
+	return Map(
+		Parenthesized(Right(Keyword("label"), Pair(WhitespaceWrap(Ident), WhitespaceWrap(Statement)))),
+		func(matched interface{}) interface{} {
+			pair := matched.(MatchedPair)
+			return &ast.LabeledStmt{
+				Label: pair.Left.(*ast.Ident),
+				Stmt:  pair.Right.(ast.Stmt),
+			}
+		})(input)
+}
+
+// LabeledStmt matches a label keyword followed by a label and a statement, such as (label outer (for ...)), and
+// returns an *ast.LabeledStmt.
+var LabeledStmt *labeledStmt
+
+// ShortVarList matches either a single identifier or a parenthesized list of up to two identifiers, such as the
+// iteration variables of a range loop or the variables of a receive clause, and returns a slice of ast.Expr.
+var ShortVarList = Map(
+	Pred(
+		Choice(Parenthesized(ZeroOrMore(WhitespaceWrap(Ident))), Map(Ident, func(matched interface{}) interface{} {
+			return []interface{}{matched}
+		})),
+		func(matched interface{}) bool {
+			return len(matched.([]interface{})) <= 2
+		}),
+	func(matched interface{}) interface{} {
+		var exprs []ast.Expr
+		for _, ident := range matched.([]interface{}) {
+			exprs = append(exprs, ident.(ast.Expr))
+		}
+		return exprs
+	})
+
+// RangeStmt matches a range keyword followed by the iteration variables, the range expression and a Block, such as
+// (range (k v) m (println k v)), and returns an *ast.RangeStmt. The iteration variables are declared with := and
+// may be a single key, a parenthesized key and value, or () to declare no variables.
+var RangeStmt = Map(
+	Parenthesized(Right(Keyword(token.RANGE.String()), Sequence(
+		WhitespaceWrap(ShortVarList),
 		WhitespaceWrap(Expr),
-		WhitespaceWrap(SimpleStmt),
 		WhitespaceWrap(Block)))),
 	func(matched interface{}) interface{} {
 		seq := matched.([]interface{})
-		return &ast.ForStmt{
-			Init: seq[0].(ast.Stmt),
-			Cond: seq[1].(ast.Expr),
-			Post: seq[2].(ast.Stmt),
-			Body: seq[3].(*ast.BlockStmt),
+		stmt := &ast.RangeStmt{
+			X:    seq[1].(ast.Expr),
+			Body: seq[2].(*ast.BlockStmt),
 		}
+		vars := seq[0].([]ast.Expr)
+		if len(vars) > 0 {
+			stmt.Key = vars[0]
+			stmt.Tok = token.DEFINE
+		}
+		if len(vars) > 1 {
+			stmt.Value = vars[1]
+		}
+		return stmt
 	})
 
+// AssignTarget matches an expression which can be assigned to, which is a name, a selector, an index expression or a
+// pointer indirection, such as x, p.X, (index xs i) or *p.
+var AssignTarget = Pred(Expr, func(matched interface{}) bool {
+	return isAssignable(matched.(ast.Expr))
+})
+
+// TargetList matches either a single AssignTarget or a parenthesized list of them, such as (a b) or
+// ((index xs i) p.X), and returns a slice of ast.Expr.
+var TargetList = Map(
+	Choice(AssignTarget, Parenthesized(OneOrMore(WhitespaceWrap(AssignTarget)))),
+	func(matched interface{}) interface{} {
+		switch v := matched.(type) {
+		case []interface{}:
+			exprs := make([]ast.Expr, len(v))
+			for i, match := range v {
+				exprs[i] = match.(ast.Expr)
+			}
+			return exprs
+		case ast.Expr:
+			return []ast.Expr{v}
+		}
+		return nil
+	})
+
+// Assignment matches an assign keyword followed by a TargetList and an ExpressionList, such as (assign x 1),
+// (assign (index xs i) v) or (assign (a b) (b a)), and returns an *ast.AssignStmt.
 var Assignment = Map(
 	Parenthesized(Right(
 		Keyword("assign"), Pair(WhitespaceWrap(
-			IdentifierList), WhitespaceWrap(
+			TargetList), WhitespaceWrap(
 			ExpressionList)))),
 	func(matched interface{}) interface{} {
 		pair := matched.(MatchedPair)
@@ -898,14 +1872,57 @@ var Assignment = Map(
 		}
 	})
 
+// AssignOp matches a compound assignment operator, which is spelled as in Go.
+var AssignOp = Choice(
+	MapConst(Literal("<<="), token.SHL_ASSIGN),
+	MapConst(Literal(">>="), token.SHR_ASSIGN),
+	MapConst(Literal("&^="), token.AND_NOT_ASSIGN),
+	MapConst(Literal("+="), token.ADD_ASSIGN),
+	MapConst(Literal("-="), token.SUB_ASSIGN),
+	MapConst(Literal("*="), token.MUL_ASSIGN),
+	MapConst(Literal("/="), token.QUO_ASSIGN),
+	MapConst(Literal("%="), token.REM_ASSIGN),
+	MapConst(Literal("&="), token.AND_ASSIGN),
+	MapConst(Literal("|="), token.OR_ASSIGN),
+	MapConst(Literal("^="), token.XOR_ASSIGN),
+)
+
+// CompoundAssignment matches an AssignOp followed by a target and a value, such as (+= sum x) or
+// (<<= (index xs i) 1), and returns an *ast.AssignStmt.
+var CompoundAssignment = Map(
+	Parenthesized(Pair(AssignOp, Pair(
+		Right(OneOrMoreWhitespaceChars(), AssignTarget),
+		Right(OneOrMoreWhitespaceChars(), Expr)))),
+	func(matched interface{}) interface{} {
+		pair := matched.(MatchedPair)
+		operands := pair.Right.(MatchedPair)
+		return &ast.AssignStmt{
+			Lhs: []ast.Expr{operands.Left.(ast.Expr)},
+			Tok: pair.Left.(token.Token),
+			Rhs: []ast.Expr{operands.Right.(ast.Expr)},
+		}
+	})
+
+// switchTag matches the tag of an ExprSwitchStmt, which may be any expression except the start of a clause.
+var switchTag = Right(
+	Not(Pair(Rune('('), Choice(Keyword(token.CASE.String()), Keyword(token.DEFAULT.String())))),
+	Expr)
+
+// ExprSwitchStmt matches a switch keyword followed by an optional InitStmt, an optional tag expression and zero or
+// more case and default clauses, such as (switch (define x ((f))) x (case (1 2) ...) (default ...)), and returns an
+// *ast.SwitchStmt. Without a tag, each case lists boolean expressions.
 var ExprSwitchStmt = Map(
-	Parenthesized(Right(Keyword("switch"), ZeroOrMore(WhitespaceWrap(
-		Choice(
-			Parenthesized(Right(Keyword("case"), Pair(WhitespaceWrap(ExpressionList), WhitespaceWrap(Block)))),
-			Parenthesized(Right(Keyword("default"), WhitespaceWrap(Block)))))))),
+	Parenthesized(Right(Keyword("switch"), Sequence(
+		Optional(WhitespaceWrap(InitStmt)),
+		Optional(WhitespaceWrap(switchTag)),
+		ZeroOrMore(WhitespaceWrap(
+			Choice(
+				Parenthesized(Right(Keyword("case"), Pair(WhitespaceWrap(ExpressionList), WhitespaceWrap(Block)))),
+				Parenthesized(Right(Keyword("default"), WhitespaceWrap(Block))))))))),
 	func(matched interface{}) interface{} {
+		seq := matched.([]interface{})
 		var clauses []ast.Stmt
-		for _, match := range matched.([]interface{}) {
+		for _, match := range seq[2].([]interface{}) {
 			switch v := match.(type) {
 			case *ast.BlockStmt:
 				clauses = append(clauses, &ast.CaseClause{
@@ -918,28 +1935,202 @@ var ExprSwitchStmt = Map(
 				})
 			}
 		}
-		return &ast.SwitchStmt{
+		stmt := &ast.SwitchStmt{
 			Body: &ast.BlockStmt{List: clauses},
 		}
+		stmt.Init, _ = seq[0].(ast.Stmt)
+		stmt.Tag, _ = seq[1].(ast.Expr)
+		return stmt
 	})
 
+// TypeSwitchGuard matches the guard of a TypeSwitchStmt. A guard such as (v x) declares v with the type of each
+// case, while a plain expression such as x, or (_ x) for a more complex one, declares nothing.
+var TypeSwitchGuard = Choice(
+	Map(Parenthesized(Pair(Ident, Right(OneOrMoreWhitespaceChars(), Expr))), func(matched interface{}) interface{} {
+		pair := matched.(MatchedPair)
+		x := &ast.TypeAssertExpr{X: pair.Right.(ast.Expr)}
+		if name := pair.Left.(*ast.Ident); name.Name != "_" {
+			return &ast.AssignStmt{
+				Lhs: []ast.Expr{name},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{x},
+			}
+		}
+		return &ast.ExprStmt{X: x}
+	}),
+	Map(Expr, func(matched interface{}) interface{} {
+		return &ast.ExprStmt{X: &ast.TypeAssertExpr{X: matched.(ast.Expr)}}
+	}),
+)
+
+// TypeList matches either a single type or a parenthesised list of types.
+var TypeList = Map(Choice(Parenthesized(OneOrMore(WhitespaceWrap(Type))), Type), func(matched interface{}) interface{} {
+	switch v := matched.(type) {
+	case []interface{}:
+		types := make([]ast.Expr, len(v))
+		for i, match := range v {
+			types[i] = match.(ast.Expr)
+		}
+		return types
+	default:
+		return []ast.Expr{v.(ast.Expr)}
+	}
+})
+
+// TypeSwitchStmt matches a type-switch keyword followed by a TypeSwitchGuard and zero or more case and default
+// clauses, such as (type-switch (v x) (case (int string) ...) (default ...)), and returns an *ast.TypeSwitchStmt.
+var TypeSwitchStmt = Map(
+	Parenthesized(Right(Literal("type-switch"), Pair(
+		Right(OneOrMoreWhitespaceChars(), TypeSwitchGuard),
+		ZeroOrMore(WhitespaceWrap(Choice(
+			Parenthesized(Right(Keyword("case"), Pair(WhitespaceWrap(TypeList), WhitespaceWrap(Block)))),
+			Parenthesized(Right(Keyword("default"), WhitespaceWrap(Block))))))))),
+	func(matched interface{}) interface{} {
+		pair := matched.(MatchedPair)
+		var clauses []ast.Stmt
+		for _, match := range pair.Right.([]interface{}) {
+			switch v := match.(type) {
+			case *ast.BlockStmt:
+				clauses = append(clauses, &ast.CaseClause{
+					Body: v.List,
+				})
+			case MatchedPair:
+				clauses = append(clauses, &ast.CaseClause{
+					List: v.Left.([]ast.Expr),
+					Body: v.Right.(*ast.BlockStmt).List,
+				})
+			}
+		}
+		return &ast.TypeSwitchStmt{
+			Assign: pair.Left.(ast.Stmt),
+			Body:   &ast.BlockStmt{List: clauses},
+		}
+	})
+
+// SelectStmt matches a select keyword followed by zero or more receive, send and default clauses and returns an
+// *ast.SelectStmt. A receive clause such as (recv (v ok) ch ...) declares its variables like a RangeStmt, a send
+// clause such as (send ch v ...) takes the operands of a SendStmt, and each clause ends with a Block.
+var SelectStmt = Map(
+	Parenthesized(Right(Keyword(token.SELECT.String()), ZeroOrMore(WhitespaceWrap(
+		Choice(
+			Map(
+				Parenthesized(Right(Keyword("recv"), Sequence(
+					WhitespaceWrap(ShortVarList),
+					WhitespaceWrap(Expr),
+					WhitespaceWrap(Block)))),
+				func(matched interface{}) interface{} {
+					seq := matched.([]interface{})
+					recv := &ast.UnaryExpr{
+						Op: token.ARROW,
+						X:  seq[1].(ast.Expr),
+					}
+					var comm ast.Stmt = &ast.ExprStmt{X: recv}
+					if vars := seq[0].([]ast.Expr); len(vars) > 0 {
+						comm = &ast.AssignStmt{
+							Lhs: vars,
+							Tok: token.DEFINE,
+							Rhs: []ast.Expr{recv},
+						}
+					}
+					return &ast.CommClause{
+						Comm: comm,
+						Body: seq[2].(*ast.BlockStmt).List,
+					}
+				}),
+			Map(
+				Parenthesized(Right(Keyword("send"), Pair(sendOperands, WhitespaceWrap(Block)))),
+				func(matched interface{}) interface{} {
+					pair := matched.(MatchedPair)
+					operands := pair.Left.(MatchedPair)
+					return &ast.CommClause{
+						Comm: &ast.SendStmt{
+							Chan:  operands.Left.(ast.Expr),
+							Value: operands.Right.(ast.Expr),
+						},
+						Body: pair.Right.(*ast.BlockStmt).List,
+					}
+				}),
+			Map(
+				Parenthesized(Right(Keyword("default"), WhitespaceWrap(Block))),
+				func(matched interface{}) interface{} {
+					return &ast.CommClause{
+						Body: matched.(*ast.BlockStmt).List,
+					}
+				})))))),
+	func(matched interface{}) interface{} {
+		var clauses []ast.Stmt
+		for _, clause := range matched.([]interface{}) {
+			clauses = append(clauses, clause.(ast.Stmt))
+		}
+		return &ast.SelectStmt{
+			Body: &ast.BlockStmt{List: clauses},
+		}
+	})
+
+// leadingComments matches the whitespace, comments and discarded forms before a declaration and returns the
+// comments as a slice of *ast.CommentGroup. As in Go, comments separated by a blank line are in different groups.
+var leadingComments = Map(
+	ZeroOrMore(Choice(Map(Choice(LineComment, BlockComment), func(matched interface{}) interface{} {
+		return newComments(matched.(string))
+	}), trivia())),
+	func(matched interface{}) interface{} {
+		var groups []*ast.CommentGroup
+		var newlines int
+		for _, m := range matched.([]interface{}) {
+			switch v := m.(type) {
+			case rune:
+				if v == '\n' {
+					newlines++
+				}
+			case []*ast.Comment:
+				if len(groups) == 0 || newlines > 1 {
+					groups = append(groups, &ast.CommentGroup{})
+				}
+				group := groups[len(groups)-1]
+				group.List = append(group.List, v...)
+				newlines = 0
+			}
+		}
+		return groups
+	})
+
+// SourceFile matches a package clause followed by zero or more ImportDecls and zero or more TopLevelDecls, and returns
+// an *ast.File. The comments before the package clause and each declaration, and the DocString of each declaration,
+// are kept in the Comments of the file, and the last group of comments before the package clause is the Doc of the
+// file. Each of them is positioned together with what it documents, so that they are printed together when the file
+// is printed with NewFileSet.
 var SourceFile = Map(
 	Sequence(
-		WhitespaceWrap(PackageClause()),
-		WhitespaceWrap(ZeroOrMore(WhitespaceWrap(ImportDecl))),
-		WhitespaceWrap(OneOrMore(WhitespaceWrap(TopLevelDecl)))),
+		Pair(leadingComments, PackageClause()),
+		ZeroOrMore(Pair(leadingComments, ImportDecl)),
+		Left(ZeroOrMore(Pair(leadingComments, TopLevelDecl)), ZeroOrMoreWhitespaceChars())),
 	func(matched interface{}) interface{} {
 		matches := matched.([]interface{})
-		pkgName := matches[0].(*ast.Ident)
-		var decls []ast.Decl
-		for _, d := range matches[1].([]interface{}) {
-			decls = append(decls, d.(ast.Decl))
+		pkg := matches[0].(MatchedPair)
+		file := &ast.File{
+			Name: pkg.Right.(*ast.Ident),
 		}
-		for _, d := range matches[2].([]interface{}) {
-			decls = append(decls, d.(ast.Decl))
+		if groups := pkg.Left.([]*ast.CommentGroup); len(groups) > 0 {
+			file.Doc = groups[len(groups)-1]
+			file.Package = declSpacing
+			positionComments(groups, file.Package)
+			file.Comments = append(file.Comments, groups...)
 		}
-		return &ast.File{
-			Name:  pkgName,
-			Decls: decls,
+		var decls []interface{}
+		decls = append(decls, matches[1].([]interface{})...)
+		decls = append(decls, matches[2].([]interface{})...)
+		for i, m := range decls {
+			pair := m.(MatchedPair)
+			decl := pair.Right.(ast.Decl)
+			groups := pair.Left.([]*ast.CommentGroup)
+			if doc := declDoc(decl); doc != nil {
+				groups = append(groups, doc)
+			}
+			if len(groups) > 0 {
+				positionDecl(decl, groups, token.Pos((i+2)*declSpacing))
+				file.Comments = append(file.Comments, groups...)
+			}
+			file.Decls = append(file.Decls, decl)
 		}
+		return file
 	})
@@ -159,6 +159,12 @@ func Test_QuotedString(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "", output.Remaining())
 	assert.Equal(t, "Hello Joe!", matched)
+	t.Run("escaped quote", func(t *testing.T) {
+		output, matched, err := p(`"say \"hi\"" rest`)
+		assert.NoError(t, err)
+		assert.Equal(t, " rest", output.Remaining())
+		assert.Equal(t, `say \"hi\"`, matched)
+	})
 }
 
 func Test_Choice(t *testing.T) {
@@ -203,6 +209,78 @@ func Test_decimalLit(t *testing.T) {
 		assert.Equal(t, " aoeu", output.Remaining())
 		assert.Equal(t, &ast.BasicLit{Kind: token.INT, Value: "12340"}, matched)
 	}
+	{
+		output, matched, err := p("1_000_000")
+		assert.NoError(t, err)
+		assert.Equal(t, "", output.Remaining())
+		assert.Equal(t, &ast.BasicLit{Kind: token.INT, Value: "1_000_000"}, matched)
+	}
+	{
+		_, _, err := p("١٢")
+		assert.Error(t, err)
+	}
+}
+
+func TestIntLit(t *testing.T) {
+	parse := stringParser(IntLit)
+	tests := []struct {
+		input     string
+		want      string
+		remaining string
+	}{
+		{"42", "42", ""},
+		{"0b1010", "0b1010", ""},
+		{"0B_1", "0B_1", ""},
+		{"0o755", "0o755", ""},
+		{"0755", "0755", ""},
+		{"0_600", "0_600", ""},
+		{"0xBadFace", "0xBadFace", ""},
+		{"0x_67_7a", "0x_67_7a", ""},
+		{"1__2", "1", "__2"},
+		{"1_", "1", "_"},
+		{"08", "0", "8"},
+		{"0b2", "0", "b2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			output, matched, err := parse(tt.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.remaining, output.Remaining())
+			assert.Equal(t, &ast.BasicLit{Kind: token.INT, Value: tt.want}, matched)
+		})
+	}
+}
+
+func TestFloatLit(t *testing.T) {
+	parse := stringParser(FloatLit)
+	tests := []string{"0.", "72.40", "072.40", "2.71828", "1.e+0", "6.67428e-11", "1E6", ".25", ".12345E+5",
+		"1_5.", "0.15e+0_2", "0x1p-2", "0x2.p10", "0x1.Fp+0", "0X.8p-0", "0X_1FFFP-16"}
+	for _, input := range tests {
+		t.Run(input, func(t *testing.T) {
+			output, matched, err := parse(input)
+			assert.NoError(t, err)
+			assert.Equal(t, "", output.Remaining())
+			assert.Equal(t, &ast.BasicLit{Kind: token.FLOAT, Value: input}, matched)
+		})
+	}
+	t.Run("hex mantissa without exponent", func(t *testing.T) {
+		_, _, err := parse("0x1.5")
+		assert.Error(t, err)
+	})
+}
+
+func TestImaginaryLit(t *testing.T) {
+	parse := stringParser(ImaginaryLit)
+	tests := []string{"0i", "0123i", "089i", "0o123i", "0xabci", "0.i", "2.71828i", "1.e+0i", "6.67428e-11i",
+		"1E6i", ".25i", ".12345E+5i", "0x1p-2i"}
+	for _, input := range tests {
+		t.Run(input, func(t *testing.T) {
+			output, matched, err := parse(input)
+			assert.NoError(t, err)
+			assert.Equal(t, "", output.Remaining())
+			assert.Equal(t, &ast.BasicLit{Kind: token.IMAG, Value: input}, matched)
+		})
+	}
 }
 
 func Test_stringLit(t *testing.T) {
@@ -211,6 +289,38 @@ func Test_stringLit(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "", output.Remaining())
 	assert.Equal(t, &ast.BasicLit{Kind: token.STRING, Value: "\"Hello, World\""}, matched)
+	valid := []string{
+		"`abc`",
+		"`\\n\n\\n`",
+		`"\a\b\f\n\r\t\v\\\""`,
+		`"\xff\u00FF\U0010FFFF"`,
+		`"\377\000"`,
+		`"日本語"`,
+	}
+	for _, input := range valid {
+		t.Run(input, func(t *testing.T) {
+			output, matched, err := p(input)
+			assert.NoError(t, err)
+			assert.Equal(t, "", output.Remaining())
+			assert.Equal(t, &ast.BasicLit{Kind: token.STRING, Value: input}, matched)
+		})
+	}
+	invalid := []string{
+		`"\'"`,
+		`"\400"`,
+		`"\uD800"`,
+		`"\U00110000"`,
+		`"\x1"`,
+		`"\q"`,
+		"\"line\nbreak\"",
+		"`unterminated",
+	}
+	for _, input := range invalid {
+		t.Run(input, func(t *testing.T) {
+			_, _, err := p(input)
+			assert.Error(t, err)
+		})
+	}
 }
 
 func TestSourceFile(t *testing.T) {
@@ -308,6 +418,156 @@ func TestSourceFile(t *testing.T) {
 	})
 }
 
+func TestParse(t *testing.T) {
+	_, err := Parse(`(package main)
+
+(func main ())
+
+(type List [(T any) (struct (items []T)))`)
+	assert.EqualError(t, err, `offset 32: wanted a declaration, got "(type List [(T any) (struct (items []T)))"`)
+
+	t.Run("first declaration", func(t *testing.T) {
+		_, err := Parse(`(package main)
+
+(func f () (int) (return 1))`)
+		assert.EqualError(t, err, `offset 16: wanted a declaration, got "(func f () (int) (return 1))"`)
+	})
+	t.Run("no declarations", func(t *testing.T) {
+		file, err := Parse(`(package main)`)
+		assert.NoError(t, err)
+		assert.Empty(t, file.Decls)
+	})
+}
+
+func TestSourceFile_comments(t *testing.T) {
+	const input = `; Copyright notice.
+
+; Package main is a demo.
+(package main)
+
+; fmt is imported for printing.
+(import "fmt")
+
+;; main prints a greeting.
+#| It is the entry point. |#
+(func main () (fmt.Println 1))
+
+(var x int) ; not carried over, since it follows the declaration`
+	_, matched, err := SourceFile(NewSource(input))
+	assert.NoError(t, err)
+	file := matched.(*ast.File)
+	doc := &ast.CommentGroup{List: []*ast.Comment{{Slash: declSpacing - 1, Text: "// Package main is a demo."}}}
+	assert.Equal(t, []*ast.CommentGroup{
+		{List: []*ast.Comment{{Slash: declSpacing - 3, Text: "// Copyright notice."}}},
+		doc,
+		{List: []*ast.Comment{{Slash: 2*declSpacing - 1, Text: "// fmt is imported for printing."}}},
+		{
+			List: []*ast.Comment{
+				{Slash: 3*declSpacing - 2, Text: "// main prints a greeting."},
+				{Slash: 3*declSpacing - 1, Text: "// It is the entry point."},
+			},
+		},
+	}, file.Comments)
+	assert.Equal(t, doc, file.Doc)
+	assert.Equal(t, token.Pos(declSpacing), file.Package)
+	assert.Len(t, file.Decls, 3)
+	assert.Equal(t, token.Pos(2*declSpacing), file.Decls[0].Pos())
+	assert.Equal(t, token.Pos(3*declSpacing), file.Decls[1].Pos())
+	assert.Equal(t, token.NoPos, file.Decls[2].Pos())
+}
+
+func TestSourceFile_docStrings(t *testing.T) {
+	const input = `(package main)
+
+; main is the entry point.
+(func main "main does nothing." ())`
+	_, matched, err := SourceFile(NewSource(input))
+	assert.NoError(t, err)
+	file := matched.(*ast.File)
+	doc := &ast.CommentGroup{List: []*ast.Comment{{Slash: 2*declSpacing - 1, Text: "// main does nothing."}}}
+	assert.Equal(t, []*ast.CommentGroup{
+		{List: []*ast.Comment{{Slash: 2*declSpacing - 2, Text: "// main is the entry point."}}},
+		doc,
+	}, file.Comments)
+	assert.Equal(t, doc, file.Decls[0].(*ast.FuncDecl).Doc)
+}
+
+func TestLineComment(t *testing.T) {
+	p := stringParser(LineComment)
+	output, matched, err := p("; a comment\n(x)")
+	assert.NoError(t, err)
+	assert.Equal(t, "\n(x)", output.Remaining())
+	assert.Equal(t, "; a comment", matched)
+}
+
+func TestBlockComment(t *testing.T) {
+	p := stringParser(BlockComment)
+	t.Run("nested", func(t *testing.T) {
+		output, matched, err := p("#| outer #| inner |# outer |#(x)")
+		assert.NoError(t, err)
+		assert.Equal(t, "(x)", output.Remaining())
+		assert.Equal(t, "#| outer #| inner |# outer |#", matched)
+	})
+	t.Run("unterminated", func(t *testing.T) {
+		_, _, err := p("#| outer #| inner |#")
+		assert.Error(t, err)
+	})
+}
+
+func TestDiscard(t *testing.T) {
+	p := stringParser(Discard)
+	tests := []struct {
+		input     string
+		remaining string
+	}{
+		{"#_x y", " y"},
+		{"#_ (f (g \")\") ';') y", " y"},
+		{"#_(f) y", " y"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			output, _, err := p(tt.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.remaining, output.Remaining())
+		})
+	}
+	t.Run("last argument", func(t *testing.T) {
+		_, matched, err := stringParser(CallExpr)("(f x #_y ; z\n)")
+		assert.NoError(t, err)
+		assert.Equal(t, newCallExpr("f", ast.NewIdent("x")), matched)
+	})
+}
+
+func TestOneOrMoreWhitespaceChars(t *testing.T) {
+	p := stringParser(OneOrMoreWhitespaceChars())
+	output, _, err := p(" ; comment\n #| block |# #_(discarded) \tx")
+	assert.NoError(t, err)
+	assert.Equal(t, "x", output.Remaining())
+	_, _, err = p("x")
+	assert.Error(t, err)
+}
+
+func Test_newComments(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"; text", []string{"// text"}},
+		{";;;text  ", []string{"// text"}},
+		{";", []string{"//"}},
+		{"#| one\n\n   two |#", []string{"// one", "//", "// two"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			var got []string
+			for _, c := range newComments(tt.text) {
+				got = append(got, c.Text)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestSExpr(t *testing.T) {
 	p := stringParser(Parenthesized(OneOrMore(WhitespaceWrap(Identifier))))
 	output, matched, err := p("(hello world)")
@@ -318,6 +578,25 @@ func TestSExpr(t *testing.T) {
 
 func Test_callExpr_Parse(t *testing.T) {
 	parse := stringParser(CallExpr)
+	t.Run("explicit instantiation", func(t *testing.T) {
+		_, matched, err := parse(`(Map[int string] xs f)`)
+		assert.Equal(t, &ast.CallExpr{
+			Fun: &ast.IndexListExpr{
+				X:       ast.NewIdent("Map"),
+				Indices: []ast.Expr{ast.NewIdent("int"), ast.NewIdent("string")},
+			},
+			Args: []ast.Expr{ast.NewIdent("xs"), ast.NewIdent("f")},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("instantiation as argument", func(t *testing.T) {
+		_, matched, err := parse(`(apply Sum[float64])`)
+		assert.Equal(t, &ast.CallExpr{
+			Fun:  ast.NewIdent("apply"),
+			Args: []ast.Expr{&ast.IndexExpr{X: ast.NewIdent("Sum"), Index: ast.NewIdent("float64")}},
+		}, matched)
+		assert.NoError(t, err)
+	})
 	t.Run("literal arguments", func(t *testing.T) {
 		_, matched, err := parse(`(println "Hello, World")`)
 		assert.Equal(t, &ast.CallExpr{
@@ -333,6 +612,48 @@ func Test_callExpr_Parse(t *testing.T) {
 		}, matched)
 		assert.NoError(t, err)
 	})
+	t.Run("spread final argument", func(t *testing.T) {
+		_, matched, err := parse(`(append xs ys...)`)
+		assert.Equal(t, &ast.CallExpr{
+			Fun:      ast.NewIdent("append"),
+			Args:     []ast.Expr{ast.NewIdent("xs"), ast.NewIdent("ys")},
+			Ellipsis: validPos,
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("type arguments", func(t *testing.T) {
+		_, matched, err := parse(`(make chan int 1)`)
+		assert.Equal(t, &ast.CallExpr{
+			Fun: ast.NewIdent("make"),
+			Args: []ast.Expr{
+				&ast.ChanType{Dir: ast.SEND | ast.RECV, Value: ast.NewIdent("int")},
+				intLit(1),
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("conversion", func(t *testing.T) {
+		_, matched, err := parse(`([]byte s)`)
+		assert.Equal(t, &ast.CallExpr{
+			Fun:  &ast.ArrayType{Elt: ast.NewIdent("byte")},
+			Args: []ast.Expr{ast.NewIdent("s")},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("immediately invoked function literal", func(t *testing.T) {
+		_, matched, err := parse(`((fn () (println 1)))`)
+		assert.Equal(t, &ast.CallExpr{
+			Fun: &ast.FuncLit{
+				Type: &ast.FuncType{Params: &ast.FieldList{}},
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.ExprStmt{X: newCallExpr("println", intLit(1))},
+					},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
 	t.Run("nested call expressions", func(t *testing.T) {
 		_, matched, err := parse(`(println "Hello" (fmt.Sprint "World"))`)
 		assert.Equal(t, &ast.CallExpr{
@@ -391,6 +712,220 @@ func TestFunctionDecl(t *testing.T) {
 	}, matched)
 	assert.NoError(t, err)
 
+	t.Run("doc string", func(t *testing.T) {
+		_, matched, err := parse("(func Max[(T any)] `Max returns a.\n\n    It is a stub.` ((a b T)) (results T) (return a))")
+		assert.NoError(t, err)
+		assert.Equal(t, &ast.CommentGroup{List: []*ast.Comment{
+			{Text: "// Max returns a."},
+			{Text: "//"},
+			{Text: "// It is a stub."},
+		}}, matched.(*ast.FuncDecl).Doc)
+	})
+	t.Run("type parameters", func(t *testing.T) {
+		_, matched, err := parse(`(func Max[(T (| ~int ~float64))] ((a b T)) (results T) (return a))`)
+		assert.Equal(t, &ast.FuncDecl{
+			Name: ast.NewIdent("Max"),
+			Type: &ast.FuncType{
+				TypeParams: &ast.FieldList{List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("T")},
+						Type: &ast.BinaryExpr{
+							X:  &ast.UnaryExpr{Op: token.TILDE, X: ast.NewIdent("int")},
+							Op: token.OR,
+							Y:  &ast.UnaryExpr{Op: token.TILDE, X: ast.NewIdent("float64")},
+						},
+					},
+				}},
+				Params: &ast.FieldList{List: []*ast.Field{
+					{Names: []*ast.Ident{ast.NewIdent("a"), ast.NewIdent("b")}, Type: ast.NewIdent("T")},
+				}},
+				Results: &ast.FieldList{List: []*ast.Field{{Type: ast.NewIdent("T")}}},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{&ast.ReturnStmt{Results: []ast.Expr{ast.NewIdent("a")}}},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("parameters and result", func(t *testing.T) {
+		_, matched, err := parse(`(func add ((a int) (b int)) (results int) (println a b))`)
+		assert.Equal(t, &ast.FuncDecl{
+			Name: ast.NewIdent("add"),
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{
+					List: []*ast.Field{
+						{Names: []*ast.Ident{ast.NewIdent("a")}, Type: ast.NewIdent("int")},
+						{Names: []*ast.Ident{ast.NewIdent("b")}, Type: ast.NewIdent("int")},
+					},
+				},
+				Results: &ast.FieldList{
+					List: []*ast.Field{
+						{Type: ast.NewIdent("int")},
+					},
+				},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ExprStmt{X: newCallExpr("println", ast.NewIdent("a"), ast.NewIdent("b"))},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("named results", func(t *testing.T) {
+		_, matched, err := parse(`(func div ((a b int)) (results (q int) (err error)) (println a b))`)
+		assert.Equal(t, &ast.FuncDecl{
+			Name: ast.NewIdent("div"),
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{
+					List: []*ast.Field{
+						{Names: []*ast.Ident{ast.NewIdent("a"), ast.NewIdent("b")}, Type: ast.NewIdent("int")},
+					},
+				},
+				Results: &ast.FieldList{
+					List: []*ast.Field{
+						{Names: []*ast.Ident{ast.NewIdent("q")}, Type: ast.NewIdent("int")},
+						{Names: []*ast.Ident{ast.NewIdent("err")}, Type: ast.NewIdent("error")},
+					},
+				},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ExprStmt{X: newCallExpr("println", ast.NewIdent("a"), ast.NewIdent("b"))},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("single statement is not a result list", func(t *testing.T) {
+		_, matched, err := parse(`(func main () (fmt.Println x))`)
+		assert.Equal(t, &ast.FuncDecl{
+			Name: ast.NewIdent("main"),
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ExprStmt{X: newCallExpr(newSelectorExpr("fmt", "Println"), ast.NewIdent("x"))},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("keywords are not result types", func(t *testing.T) {
+		_, matched, err := parse(`(func main () (inc i) (println i))`)
+		assert.Equal(t, &ast.FuncDecl{
+			Name: ast.NewIdent("main"),
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.IncDecStmt{X: ast.NewIdent("i"), Tok: token.INC},
+					&ast.ExprStmt{X: newCallExpr("println", ast.NewIdent("i"))},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("space before type parameters", func(t *testing.T) {
+		_, matched, err := parse(`(func Id [(T any)] ((x T)) (results T) (return x))`)
+		assert.NoError(t, err)
+		assert.Equal(t, &ast.FieldList{List: []*ast.Field{
+			{Names: []*ast.Ident{ast.NewIdent("T")}, Type: ast.NewIdent("any")},
+		}}, matched.(*ast.FuncDecl).Type.TypeParams)
+	})
+	t.Run("call statements are not a result list", func(t *testing.T) {
+		_, matched, err := parse(`(func main () (fmt.Println x) (fmt.Println y))`)
+		assert.Equal(t, &ast.FuncDecl{
+			Name: ast.NewIdent("main"),
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ExprStmt{X: newCallExpr(newSelectorExpr("fmt", "Println"), ast.NewIdent("x"))},
+					&ast.ExprStmt{X: newCallExpr(newSelectorExpr("fmt", "Println"), ast.NewIdent("y"))},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+		_, matched, err = parse(`(func main () (foo) (bar))`)
+		assert.Equal(t, &ast.FuncDecl{
+			Name: ast.NewIdent("main"),
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ExprStmt{X: newCallExpr("foo")},
+					&ast.ExprStmt{X: newCallExpr("bar")},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("bare result list", func(t *testing.T) {
+		_, _, err := parse(`(func f () (int) (return 1))`)
+		assert.Error(t, err)
+		_, _, err = parse(`(func f () (int error) (return 1 nil))`)
+		assert.Error(t, err)
+	})
+	t.Run("empty result list", func(t *testing.T) {
+		_, matched, err := parse(`(func main () (results) (setup) (run))`)
+		assert.Equal(t, &ast.FuncDecl{
+			Name: ast.NewIdent("main"),
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ExprStmt{X: newCallExpr("setup")},
+					&ast.ExprStmt{X: newCallExpr("run")},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+}
+
+func TestParameterList(t *testing.T) {
+	parse := stringParser(ParameterList)
+	t.Run("empty", func(t *testing.T) {
+		_, matched, err := parse(`()`)
+		assert.Equal(t, &ast.FieldList{}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("unnamed", func(t *testing.T) {
+		_, matched, err := parse(`(int fmt.Stringer)`)
+		assert.Equal(t, &ast.FieldList{
+			List: []*ast.Field{
+				{Type: ast.NewIdent("int")},
+				{Type: newSelectorExpr("fmt", "Stringer")},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("variadic", func(t *testing.T) {
+		_, matched, err := parse(`((sep string) (elems ...string))`)
+		assert.Equal(t, &ast.FieldList{
+			List: []*ast.Field{
+				{Names: []*ast.Ident{ast.NewIdent("sep")}, Type: ast.NewIdent("string")},
+				{Names: []*ast.Ident{ast.NewIdent("elems")}, Type: &ast.Ellipsis{Elt: ast.NewIdent("string")}},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("grouped names", func(t *testing.T) {
+		_, matched, err := parse(`((x y int) (s string))`)
+		assert.Equal(t, &ast.FieldList{
+			List: []*ast.Field{
+				{Names: []*ast.Ident{ast.NewIdent("x"), ast.NewIdent("y")}, Type: ast.NewIdent("int")},
+				{Names: []*ast.Ident{ast.NewIdent("s")}, Type: ast.NewIdent("string")},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
 }
 
 func TestList(t *testing.T) {
@@ -494,6 +1029,141 @@ func Test_binaryExpr_Parse(t *testing.T) {
 		}, matched)
 		assert.NoError(t, err)
 	})
+	tests := []struct {
+		op   string
+		want token.Token
+	}{
+		{"+", token.ADD},
+		{"-", token.SUB},
+		{"*", token.MUL},
+		{"/", token.QUO},
+		{"%", token.REM},
+		{"&", token.AND},
+		{"|", token.OR},
+		{"^", token.XOR},
+		{"&^", token.AND_NOT},
+		{"<<", token.SHL},
+		{">>", token.SHR},
+		{"&&", token.LAND},
+		{"||", token.LOR},
+		{"=", token.EQL},
+		{"==", token.EQL},
+		{"!=", token.NEQ},
+		{"<", token.LSS},
+		{"<=", token.LEQ},
+		{">", token.GTR},
+		{">=", token.GEQ},
+	}
+	for _, tt := range tests {
+		t.Run(tt.op, func(t *testing.T) {
+			_, matched, err := parse("(" + tt.op + " x y)")
+			assert.Equal(t, &ast.BinaryExpr{
+				X:  ast.NewIdent("x"),
+				Op: tt.want,
+				Y:  ast.NewIdent("y"),
+			}, matched)
+			assert.NoError(t, err)
+		})
+	}
+	t.Run("variadic", func(t *testing.T) {
+		_, matched, err := parse(`(- a b c)`)
+		assert.Equal(t, &ast.BinaryExpr{
+			X:  &ast.BinaryExpr{X: ast.NewIdent("a"), Op: token.SUB, Y: ast.NewIdent("b")},
+			Op: token.SUB,
+			Y:  ast.NewIdent("c"),
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("negation", func(t *testing.T) {
+		_, matched, err := parse(`(- x)`)
+		assert.Equal(t, &ast.UnaryExpr{Op: token.SUB, X: ast.NewIdent("x")}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("single operand", func(t *testing.T) {
+		_, _, err := parse(`(* x)`)
+		assert.Error(t, err)
+	})
+	t.Run("chained comparison", func(t *testing.T) {
+		_, matched, err := parse(`(<= 0 i (len xs))`)
+		assert.Equal(t, &ast.BinaryExpr{
+			X:  &ast.BinaryExpr{X: intLit(0), Op: token.LEQ, Y: ast.NewIdent("i")},
+			Op: token.LAND,
+			Y:  &ast.BinaryExpr{X: ast.NewIdent("i"), Op: token.LEQ, Y: newCallExpr("len", ast.NewIdent("xs"))},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("chained comparison with constant operand", func(t *testing.T) {
+		_, matched, err := parse(`(< lo (- 1) hi)`)
+		minusOne := &ast.UnaryExpr{Op: token.SUB, X: intLit(1)}
+		assert.Equal(t, &ast.BinaryExpr{
+			X:  &ast.BinaryExpr{X: ast.NewIdent("lo"), Op: token.LSS, Y: minusOne},
+			Op: token.LAND,
+			Y:  &ast.BinaryExpr{X: minusOne, Op: token.LSS, Y: ast.NewIdent("hi")},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("chained comparison with impure operand", func(t *testing.T) {
+		_, matched, err := parse(`(< (f) (g cmp0) n)`)
+		assert.Equal(t, &ast.CallExpr{
+			Fun: &ast.FuncLit{
+				Type: &ast.FuncType{
+					Params:  &ast.FieldList{},
+					Results: &ast.FieldList{List: []*ast.Field{{Type: ast.NewIdent("bool")}}},
+				},
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.AssignStmt{
+							Lhs: []ast.Expr{ast.NewIdent("cmp1"), ast.NewIdent("cmp2")},
+							Tok: token.DEFINE,
+							Rhs: []ast.Expr{newCallExpr("f"), newCallExpr("g", ast.NewIdent("cmp0"))},
+						},
+						&ast.ReturnStmt{Results: []ast.Expr{&ast.BinaryExpr{
+							X:  &ast.BinaryExpr{X: ast.NewIdent("cmp1"), Op: token.LSS, Y: ast.NewIdent("cmp2")},
+							Op: token.LAND,
+							Y:  &ast.BinaryExpr{X: ast.NewIdent("cmp2"), Op: token.LSS, Y: ast.NewIdent("n")},
+						}}},
+					},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("chained comparison short-circuits", func(t *testing.T) {
+		_, matched, err := parse(`(< (f) (g) (h))`)
+		cmp := func(x, y string) ast.Expr {
+			return &ast.BinaryExpr{X: ast.NewIdent(x), Op: token.LSS, Y: ast.NewIdent(y)}
+		}
+		assert.Equal(t, &ast.CallExpr{
+			Fun: &ast.FuncLit{
+				Type: &ast.FuncType{
+					Params:  &ast.FieldList{},
+					Results: &ast.FieldList{List: []*ast.Field{{Type: ast.NewIdent("bool")}}},
+				},
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.AssignStmt{
+							Lhs: []ast.Expr{ast.NewIdent("cmp0"), ast.NewIdent("cmp1")},
+							Tok: token.DEFINE,
+							Rhs: []ast.Expr{newCallExpr("f"), newCallExpr("g")},
+						},
+						&ast.IfStmt{
+							Cond: &ast.UnaryExpr{Op: token.NOT, X: &ast.ParenExpr{X: cmp("cmp0", "cmp1")}},
+							Body: &ast.BlockStmt{List: []ast.Stmt{
+								&ast.ReturnStmt{Results: []ast.Expr{ast.NewIdent("false")}},
+							}},
+						},
+						&ast.AssignStmt{
+							Lhs: []ast.Expr{ast.NewIdent("cmp2")},
+							Tok: token.DEFINE,
+							Rhs: []ast.Expr{newCallExpr("h")},
+						},
+						&ast.ReturnStmt{Results: []ast.Expr{cmp("cmp1", "cmp2")}},
+					},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
 }
 
 func Test_selector_Parse(t *testing.T) {
@@ -528,6 +1198,18 @@ func Test_selector_Parse(t *testing.T) {
 		}, matched)
 		assert.NoError(t, err)
 	})
+	t.Run("variadic call", func(t *testing.T) {
+		_, matched, err := parse(`(sel a b (C xs...))`)
+		assert.Equal(t, &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   newSelectorExpr("a", "b"),
+				Sel: ast.NewIdent("C"),
+			},
+			Args:     []ast.Expr{ast.NewIdent("xs")},
+			Ellipsis: validPos,
+		}, matched)
+		assert.NoError(t, err)
+	})
 	t.Run("sel on expr", func(t *testing.T) {
 		_, matched, err := parse(`(sel (now) (Unix))`)
 		assert.Equal(t, &ast.CallExpr{
@@ -546,8 +1228,33 @@ func Test_selector_Parse(t *testing.T) {
 
 func Test_structType_Parse(t *testing.T) {
 	parse := stringParser(StructType)
-	t.Run("simple", func(t *testing.T) {
-		_, matched, err := parse(`(struct (Field1 int) (Field2 string))`)
+	t.Run("keyword prefix", func(t *testing.T) {
+		_, _, err := parse(`(structure a)`)
+		assert.Error(t, err)
+		_, matched, err := stringParser(CallExpr)(`(println (structure a))`)
+		assert.Equal(t, newCallExpr("println", newCallExpr("structure", ast.NewIdent("a"))), matched)
+		assert.NoError(t, err)
+	})
+	t.Run("type literals", func(t *testing.T) {
+		_, matched, err := parse(`(struct (Next *Node) (Children []*Node))`)
+		assert.Equal(t, &ast.StructType{
+			Fields: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{{Name: "Next"}},
+						Type:  &ast.StarExpr{X: ast.NewIdent("Node")},
+					},
+					{
+						Names: []*ast.Ident{{Name: "Children"}},
+						Type:  &ast.ArrayType{Elt: &ast.StarExpr{X: ast.NewIdent("Node")}},
+					},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("simple", func(t *testing.T) {
+		_, matched, err := parse(`(struct (Field1 int) (Field2 string))`)
 		assert.Equal(t, &ast.StructType{
 			Fields: &ast.FieldList{
 				List: []*ast.Field{
@@ -564,10 +1271,182 @@ func Test_structType_Parse(t *testing.T) {
 		}, matched)
 		assert.NoError(t, err)
 	})
+	t.Run("multiple names", func(t *testing.T) {
+		_, matched, err := parse(`(struct (X Y int) (index int))`)
+		assert.Equal(t, &ast.StructType{
+			Fields: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("X"), ast.NewIdent("Y")},
+						Type:  ast.NewIdent("int"),
+					},
+					{
+						Names: []*ast.Ident{ast.NewIdent("index")},
+						Type:  ast.NewIdent("int"),
+					},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("tags", func(t *testing.T) {
+		_, matched, err := parse(`(struct (Name string "json:\"name\"") (*Node "embedded"))`)
+		assert.Equal(t, &ast.StructType{
+			Fields: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("Name")},
+						Type:  ast.NewIdent("string"),
+						Tag:   strLit(`"json:\"name\""`),
+					},
+					{
+						Type: &ast.StarExpr{X: ast.NewIdent("Node")},
+						Tag:  strLit(`"embedded"`),
+					},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("embedded fields", func(t *testing.T) {
+		_, matched, err := parse(`(struct sync.Mutex *Base (Count int))`)
+		assert.Equal(t, &ast.StructType{
+			Fields: &ast.FieldList{
+				List: []*ast.Field{
+					{Type: newSelectorExpr("sync", "Mutex")},
+					{Type: &ast.StarExpr{X: ast.NewIdent("Base")}},
+					{
+						Names: []*ast.Ident{ast.NewIdent("Count")},
+						Type:  ast.NewIdent("int"),
+					},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("anonymous struct field", func(t *testing.T) {
+		_, matched, err := parse(`(struct (Point (struct (X Y int))))`)
+		assert.Equal(t, &ast.StructType{
+			Fields: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("Point")},
+						Type: &ast.StructType{
+							Fields: &ast.FieldList{
+								List: []*ast.Field{
+									{
+										Names: []*ast.Ident{ast.NewIdent("X"), ast.NewIdent("Y")},
+										Type:  ast.NewIdent("int"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
 }
 
 func Test_typeDecl_Parse(t *testing.T) {
 	parse := stringParser(TypeDecl)
+	t.Run("space before type parameters", func(t *testing.T) {
+		_, matched, err := parse(`(type List [(T any)] (struct (items []T)))`)
+		assert.Equal(t, &ast.GenDecl{
+			Tok: token.TYPE,
+			Specs: []ast.Spec{
+				&ast.TypeSpec{
+					Name: ast.NewIdent("List"),
+					TypeParams: &ast.FieldList{List: []*ast.Field{
+						{Names: []*ast.Ident{ast.NewIdent("T")}, Type: ast.NewIdent("any")},
+					}},
+					Type: &ast.StructType{
+						Fields: &ast.FieldList{List: []*ast.Field{
+							{Names: []*ast.Ident{ast.NewIdent("items")}, Type: &ast.ArrayType{Elt: ast.NewIdent("T")}},
+						}},
+					},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("doc string", func(t *testing.T) {
+		_, matched, err := parse(`(type Celsius "Celsius is a temperature." float64)`)
+		assert.Equal(t, &ast.GenDecl{
+			Doc: &ast.CommentGroup{List: []*ast.Comment{{Text: "// Celsius is a temperature."}}},
+			Tok: token.TYPE,
+			Specs: []ast.Spec{
+				&ast.TypeSpec{
+					Name: ast.NewIdent("Celsius"),
+					Type: ast.NewIdent("float64"),
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("type parameters", func(t *testing.T) {
+		_, matched, err := parse(`(type Pair[(K comparable) (V any)] (struct (Key K) (Value V)))`)
+		assert.Equal(t, &ast.GenDecl{
+			Tok: token.TYPE,
+			Specs: []ast.Spec{
+				&ast.TypeSpec{
+					Name: ast.NewIdent("Pair"),
+					TypeParams: &ast.FieldList{List: []*ast.Field{
+						{Names: []*ast.Ident{ast.NewIdent("K")}, Type: ast.NewIdent("comparable")},
+						{Names: []*ast.Ident{ast.NewIdent("V")}, Type: ast.NewIdent("any")},
+					}},
+					Type: &ast.StructType{
+						Fields: &ast.FieldList{List: []*ast.Field{
+							{Names: []*ast.Ident{ast.NewIdent("Key")}, Type: ast.NewIdent("K")},
+							{Names: []*ast.Ident{ast.NewIdent("Value")}, Type: ast.NewIdent("V")},
+						}},
+					},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("defined type", func(t *testing.T) {
+		_, matched, err := parse(`(type Celsius float64)`)
+		assert.Equal(t, &ast.GenDecl{
+			Tok: token.TYPE,
+			Specs: []ast.Spec{
+				&ast.TypeSpec{
+					Name: ast.NewIdent("Celsius"),
+					Type: ast.NewIdent("float64"),
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("interface", func(t *testing.T) {
+		_, matched, err := parse(`(type Stringer (interface (String () (string))))`)
+		assert.Equal(t, &ast.GenDecl{
+			Tok: token.TYPE,
+			Specs: []ast.Spec{
+				&ast.TypeSpec{
+					Name: ast.NewIdent("Stringer"),
+					Type: &ast.InterfaceType{
+						Methods: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Names: []*ast.Ident{ast.NewIdent("String")},
+									Type: &ast.FuncType{
+										Params: &ast.FieldList{},
+										Results: &ast.FieldList{
+											List: []*ast.Field{{Type: ast.NewIdent("string")}},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
 	t.Run("struct", func(t *testing.T) {
 		_, matched, err := parse(`(type MyStruct (struct (Field string)))`)
 		assert.Equal(t, &ast.GenDecl{
@@ -592,6 +1471,177 @@ func Test_typeDecl_Parse(t *testing.T) {
 	})
 }
 
+func Test__type_Parse(t *testing.T) {
+	parse := stringParser(Type)
+	tests := []struct {
+		name  string
+		input string
+		want  ast.Expr
+	}{
+		{"name", "int", ast.NewIdent("int")},
+		{"qualified name", "time.Time", newSelectorExpr("time", "Time")},
+		{"pointer", "*T", &ast.StarExpr{X: ast.NewIdent("T")}},
+		{"slice", "[]byte", &ast.ArrayType{Elt: ast.NewIdent("byte")}},
+		{"array", "[4]int", &ast.ArrayType{Len: intLit(4), Elt: ast.NewIdent("int")}},
+		{"array with constant length", "[N]int", &ast.ArrayType{Len: ast.NewIdent("N"), Elt: ast.NewIdent("int")}},
+		{"array with inferred length", "[...]int", &ast.ArrayType{Len: &ast.Ellipsis{}, Elt: ast.NewIdent("int")}},
+		{"map", "map[string][]int", &ast.MapType{
+			Key:   ast.NewIdent("string"),
+			Value: &ast.ArrayType{Elt: ast.NewIdent("int")},
+		}},
+		{"generic", "List[int]", &ast.IndexExpr{X: ast.NewIdent("List"), Index: ast.NewIdent("int")}},
+		{"generic with multiple arguments", "maps.Map[string []int]", &ast.IndexListExpr{
+			X:       newSelectorExpr("maps", "Map"),
+			Indices: []ast.Expr{ast.NewIdent("string"), &ast.ArrayType{Elt: ast.NewIdent("int")}},
+		}},
+		{"channel", "chan T", &ast.ChanType{Dir: ast.SEND | ast.RECV, Value: ast.NewIdent("T")}},
+		{"send-only channel", "chan<- T", &ast.ChanType{Dir: ast.SEND, Value: ast.NewIdent("T")}},
+		{"receive-only channel", "<-chan T", &ast.ChanType{Dir: ast.RECV, Value: ast.NewIdent("T")}},
+		{"channel of receive-only channels", "chan <-chan T", &ast.ChanType{
+			Dir:   ast.SEND | ast.RECV,
+			Value: &ast.ChanType{Dir: ast.RECV, Value: ast.NewIdent("T")},
+		}},
+		{"function", "(func (int) (error))", &ast.FuncType{
+			Params:  &ast.FieldList{List: []*ast.Field{{Type: ast.NewIdent("int")}}},
+			Results: &ast.FieldList{List: []*ast.Field{{Type: ast.NewIdent("error")}}},
+		}},
+		{"function with results form", "(func (int) (results (n int) error))", &ast.FuncType{
+			Params: &ast.FieldList{List: []*ast.Field{{Type: ast.NewIdent("int")}}},
+			Results: &ast.FieldList{List: []*ast.Field{
+				{Names: []*ast.Ident{ast.NewIdent("n")}, Type: ast.NewIdent("int")},
+				{Type: ast.NewIdent("error")},
+			}},
+		}},
+		{"function without results", "(func ((w io.Writer)))", &ast.FuncType{
+			Params: &ast.FieldList{List: []*ast.Field{
+				{Names: []*ast.Ident{ast.NewIdent("w")}, Type: newSelectorExpr("io", "Writer")},
+			}},
+		}},
+		{"struct", "(struct (X int))", &ast.StructType{
+			Fields: &ast.FieldList{List: []*ast.Field{
+				{Names: []*ast.Ident{ast.NewIdent("X")}, Type: ast.NewIdent("int")},
+			}},
+		}},
+		{"empty struct", "(struct)", &ast.StructType{Fields: &ast.FieldList{Opening: validPos, Closing: validPos}}},
+		{"interface", "(interface)", &ast.InterfaceType{Methods: &ast.FieldList{Opening: validPos, Closing: validPos}}},
+		{"channel of empty structs", "chan (struct)", &ast.ChanType{
+			Dir:   ast.SEND | ast.RECV,
+			Value: &ast.StructType{Fields: &ast.FieldList{Opening: validPos, Closing: validPos}},
+		}},
+		{"nested", "map[string]*[]chan<- (func ())", &ast.MapType{
+			Key: ast.NewIdent("string"),
+			Value: &ast.StarExpr{X: &ast.ArrayType{Elt: &ast.ChanType{
+				Dir:   ast.SEND,
+				Value: &ast.FuncType{Params: &ast.FieldList{}},
+			}}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, matched, err := parse(tt.input)
+			assert.NoError(t, err)
+			assert.Equal(t, "", output.Remaining())
+			assert.Equal(t, tt.want, matched)
+		})
+	}
+	t.Run("keyword", func(t *testing.T) {
+		_, _, err := parse("var")
+		assert.Error(t, err)
+	})
+}
+
+func Test_interfaceType_Parse(t *testing.T) {
+	parse := stringParser(InterfaceType)
+	t.Run("empty", func(t *testing.T) {
+		_, matched, err := parse(`(interface)`)
+		assert.Equal(t, &ast.InterfaceType{
+			Methods: &ast.FieldList{Opening: validPos, Closing: validPos},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("methods", func(t *testing.T) {
+		_, matched, err := parse(`(interface (Area () (float64)) (Scale ((f float64))))`)
+		assert.Equal(t, &ast.InterfaceType{
+			Methods: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("Area")},
+						Type: &ast.FuncType{
+							Params: &ast.FieldList{},
+							Results: &ast.FieldList{
+								List: []*ast.Field{{Type: ast.NewIdent("float64")}},
+							},
+						},
+					},
+					{
+						Names: []*ast.Ident{ast.NewIdent("Scale")},
+						Type: &ast.FuncType{
+							Params: &ast.FieldList{
+								List: []*ast.Field{
+									{Names: []*ast.Ident{ast.NewIdent("f")}, Type: ast.NewIdent("float64")},
+								},
+							},
+						},
+					},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("results form", func(t *testing.T) {
+		_, matched, err := parse(`(interface (Area () (results float64)))`)
+		assert.Equal(t, &ast.InterfaceType{
+			Methods: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("Area")},
+						Type: &ast.FuncType{
+							Params: &ast.FieldList{},
+							Results: &ast.FieldList{
+								List: []*ast.Field{{Type: ast.NewIdent("float64")}},
+							},
+						},
+					},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("embedded interfaces", func(t *testing.T) {
+		_, matched, err := parse(`(interface io.Reader Shape)`)
+		assert.Equal(t, &ast.InterfaceType{
+			Methods: &ast.FieldList{
+				List: []*ast.Field{
+					{Type: newSelectorExpr("io", "Reader")},
+					{Type: ast.NewIdent("Shape")},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("union", func(t *testing.T) {
+		_, matched, err := parse(`(interface (| ~int int8 ~float64))`)
+		assert.Equal(t, &ast.InterfaceType{
+			Methods: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Type: &ast.BinaryExpr{
+							X: &ast.BinaryExpr{
+								X:  &ast.UnaryExpr{Op: token.TILDE, X: ast.NewIdent("int")},
+								Op: token.OR,
+								Y:  ast.NewIdent("int8"),
+							},
+							Op: token.OR,
+							Y:  &ast.UnaryExpr{Op: token.TILDE, X: ast.NewIdent("float64")},
+						},
+					},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+}
+
 func TestSource_Advance(t *testing.T) {
 	s := "Hello"
 	source := NewSource(s)
@@ -691,6 +1741,64 @@ func TestIfStmt(t *testing.T) {
 		}, matched)
 		assert.NoError(t, err)
 	})
+	t.Run("init statement", func(t *testing.T) {
+		_, matched, err := parse(`(if (define err ((f))) (!= err nil) (return err))`)
+		assert.Equal(t, &ast.IfStmt{
+			Init: &ast.AssignStmt{
+				Lhs: []ast.Expr{ast.NewIdent("err")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{newCallExpr("f")},
+			},
+			Cond: &ast.BinaryExpr{X: ast.NewIdent("err"), Op: token.NEQ, Y: ast.NewIdent("nil")},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{Results: []ast.Expr{ast.NewIdent("err")}},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("else if chain", func(t *testing.T) {
+		_, matched, err := parse(`(if (< x 0) (println "negative")
+    (elif (= x 0) (println "zero"))
+    (elif (define y ((f x))) (> y 1) (println "big"))
+    (println "small"))`)
+		assert.Equal(t, &ast.IfStmt{
+			Cond: &ast.BinaryExpr{X: ast.NewIdent("x"), Op: token.LSS, Y: intLit(0)},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ExprStmt{X: newCallExpr("println", strLit(`"negative"`))},
+				},
+			},
+			Else: &ast.IfStmt{
+				Cond: &ast.BinaryExpr{X: ast.NewIdent("x"), Op: token.EQL, Y: intLit(0)},
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.ExprStmt{X: newCallExpr("println", strLit(`"zero"`))},
+					},
+				},
+				Else: &ast.IfStmt{
+					Init: &ast.AssignStmt{
+						Lhs: []ast.Expr{ast.NewIdent("y")},
+						Tok: token.DEFINE,
+						Rhs: []ast.Expr{newCallExpr("f", ast.NewIdent("x"))},
+					},
+					Cond: &ast.BinaryExpr{X: ast.NewIdent("y"), Op: token.GTR, Y: intLit(1)},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ExprStmt{X: newCallExpr("println", strLit(`"big"`))},
+						},
+					},
+					Else: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ExprStmt{X: newCallExpr("println", strLit(`"small"`))},
+						},
+					},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
 }
 
 func TestDoExpr(t *testing.T) {
@@ -869,6 +1977,51 @@ func TestUnaryExpr(t *testing.T) {
 		}, matched)
 		assert.NoError(t, err)
 	})
+	t.Run("receive", func(t *testing.T) {
+		_, matched, err := parse(`<-ch`)
+		assert.Equal(t, &ast.UnaryExpr{
+			Op: token.ARROW,
+			X:  ast.NewIdent("ch"),
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("operators", func(t *testing.T) {
+		tests := []struct {
+			input string
+			want  token.Token
+		}{
+			{"-x", token.SUB},
+			{"+x", token.ADD},
+			{"!x", token.NOT},
+			{"^x", token.XOR},
+		}
+		for _, tt := range tests {
+			_, matched, err := parse(tt.input)
+			assert.Equal(t, &ast.UnaryExpr{Op: tt.want, X: ast.NewIdent("x")}, matched)
+			assert.NoError(t, err)
+		}
+	})
+	t.Run("negated expression", func(t *testing.T) {
+		_, matched, err := parse(`-(+ a b)`)
+		assert.Equal(t, &ast.UnaryExpr{
+			Op: token.SUB,
+			X:  &ast.BinaryExpr{X: ast.NewIdent("a"), Op: token.ADD, Y: ast.NewIdent("b")},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("pointer indirection", func(t *testing.T) {
+		_, matched, err := parse(`**p`)
+		assert.Equal(t, &ast.StarExpr{X: &ast.StarExpr{X: ast.NewIdent("p")}}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("composite literal", func(t *testing.T) {
+		_, matched, err := parse(`&(lit Point)`)
+		assert.Equal(t, &ast.UnaryExpr{
+			Op: token.AND,
+			X:  &ast.CompositeLit{Type: ast.NewIdent("Point")},
+		}, matched)
+		assert.NoError(t, err)
+	})
 }
 
 func TestDeclStmt(t *testing.T) {
@@ -886,39 +2039,194 @@ func TestDeclStmt(t *testing.T) {
 		},
 	}, matched)
 	assert.NoError(t, err)
-}
-
-func Test_escapedChar(t *testing.T) {
-	parse := stringParser(escapedChar)
-	_, matched, err := parse(`\a`)
-	assert.Equal(t, `\a`, matched)
-	assert.NoError(t, err)
-}
-
-func TestRuneLit(t *testing.T) {
-	parse := stringParser(RuneLit)
-	t.Run("escaped char", func(t *testing.T) {
-		_, matched, err := parse(`'\n'`)
-		assert.Equal(t, &ast.BasicLit{
-			Kind:  token.CHAR,
-			Value: "'\\n'",
+	t.Run("type literal", func(t *testing.T) {
+		_, matched, err := parse(`(var counts map[string]int)`)
+		assert.Equal(t, &ast.DeclStmt{
+			Decl: &ast.GenDecl{
+				Tok: token.VAR,
+				Specs: []ast.Spec{
+					&ast.ValueSpec{
+						Names: []*ast.Ident{ast.NewIdent("counts")},
+						Type:  &ast.MapType{Key: ast.NewIdent("string"), Value: ast.NewIdent("int")},
+					},
+				},
+			},
 		}, matched)
 		assert.NoError(t, err)
 	})
-	t.Run("unicode value", func(t *testing.T) {
-		_, matched, err := parse(`'c'`)
-		assert.Equal(t, &ast.BasicLit{
-			Kind:  token.CHAR,
-			Value: "'c'",
+	t.Run("doc string", func(t *testing.T) {
+		_, matched, err := parse(`(var "x is dropped." x int)`)
+		assert.NoError(t, err)
+		assert.Nil(t, matched.(*ast.DeclStmt).Decl.(*ast.GenDecl).Doc)
+	})
+	t.Run("const", func(t *testing.T) {
+		_, matched, err := parse(`(const max = 10)`)
+		assert.Equal(t, &ast.DeclStmt{
+			Decl: &ast.GenDecl{
+				Tok: token.CONST,
+				Specs: []ast.Spec{
+					&ast.ValueSpec{
+						Names:  []*ast.Ident{ast.NewIdent("max")},
+						Values: []ast.Expr{intLit(10)},
+					},
+				},
+			},
 		}, matched)
 		assert.NoError(t, err)
 	})
 }
 
-func TestIncDecStmt(t *testing.T) {
-	parse := stringParser(IncDecStmt)
-	t.Run("inc", func(t *testing.T) {
-		_, matched, err := parse(`(inc i)`)
+func TestVarDecl(t *testing.T) {
+	parse := stringParser(VarDecl)
+	t.Run("doc string", func(t *testing.T) {
+		_, matched, err := parse(`(var "x is a variable." x int)`)
+		assert.Equal(t, &ast.GenDecl{
+			Doc: &ast.CommentGroup{List: []*ast.Comment{{Text: "// x is a variable."}}},
+			Tok: token.VAR,
+			Specs: []ast.Spec{
+				&ast.ValueSpec{
+					Names: []*ast.Ident{ast.NewIdent("x")},
+					Type:  ast.NewIdent("int"),
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("initialiser without type", func(t *testing.T) {
+		_, matched, err := parse(`(var x = ((f)))`)
+		assert.Equal(t, &ast.GenDecl{
+			Tok: token.VAR,
+			Specs: []ast.Spec{
+				&ast.ValueSpec{
+					Names:  []*ast.Ident{ast.NewIdent("x")},
+					Values: []ast.Expr{newCallExpr("f")},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("multiple names with type and values", func(t *testing.T) {
+		_, matched, err := parse(`(var (a b) int = (1 2))`)
+		assert.Equal(t, &ast.GenDecl{
+			Tok: token.VAR,
+			Specs: []ast.Spec{
+				&ast.ValueSpec{
+					Names:  []*ast.Ident{ast.NewIdent("a"), ast.NewIdent("b")},
+					Type:   ast.NewIdent("int"),
+					Values: []ast.Expr{intLit(1), intLit(2)},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("group", func(t *testing.T) {
+		_, matched, err := parse(`(var (x int) (y = "y"))`)
+		assert.Equal(t, &ast.GenDecl{
+			Tok:    token.VAR,
+			Lparen: validPos,
+			Specs: []ast.Spec{
+				&ast.ValueSpec{
+					Names: []*ast.Ident{ast.NewIdent("x")},
+					Type:  ast.NewIdent("int"),
+				},
+				&ast.ValueSpec{
+					Names:  []*ast.Ident{ast.NewIdent("y")},
+					Values: []ast.Expr{strLit(`"y"`)},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("group with single spec", func(t *testing.T) {
+		_, matched, err := parse(`(var (x int))`)
+		assert.Equal(t, &ast.GenDecl{
+			Tok:    token.VAR,
+			Lparen: validPos,
+			Specs: []ast.Spec{
+				&ast.ValueSpec{
+					Names: []*ast.Ident{ast.NewIdent("x")},
+					Type:  ast.NewIdent("int"),
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+}
+
+func TestConstDecl(t *testing.T) {
+	parse := stringParser(ConstDecl)
+	_, matched, err := parse(`(const (Red Color = iota) (Green) (Blue))`)
+	assert.Equal(t, &ast.GenDecl{
+		Tok:    token.CONST,
+		Lparen: validPos,
+		Specs: []ast.Spec{
+			&ast.ValueSpec{
+				Names:  []*ast.Ident{ast.NewIdent("Red")},
+				Type:   ast.NewIdent("Color"),
+				Values: []ast.Expr{ast.NewIdent("iota")},
+			},
+			&ast.ValueSpec{Names: []*ast.Ident{ast.NewIdent("Green")}},
+			&ast.ValueSpec{Names: []*ast.Ident{ast.NewIdent("Blue")}},
+		},
+	}, matched)
+	assert.NoError(t, err)
+}
+
+func TestConstDecl_docString(t *testing.T) {
+	parse := stringParser(ConstDecl)
+	_, matched, err := parse(`(const "Colors." (Red Color = iota) (Green))`)
+	assert.NoError(t, err)
+	decl := matched.(*ast.GenDecl)
+	assert.Equal(t, &ast.CommentGroup{List: []*ast.Comment{{Text: "// Colors."}}}, decl.Doc)
+	assert.Len(t, decl.Specs, 2)
+}
+
+func Test_escapedChar(t *testing.T) {
+	parse := stringParser(escapedChar)
+	_, matched, err := parse(`\a`)
+	assert.Equal(t, `\a`, matched)
+	assert.NoError(t, err)
+}
+
+func TestRuneLit(t *testing.T) {
+	parse := stringParser(RuneLit)
+	t.Run("escaped char", func(t *testing.T) {
+		_, matched, err := parse(`'\n'`)
+		assert.Equal(t, &ast.BasicLit{
+			Kind:  token.CHAR,
+			Value: "'\\n'",
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("unicode value", func(t *testing.T) {
+		_, matched, err := parse(`'c'`)
+		assert.Equal(t, &ast.BasicLit{
+			Kind:  token.CHAR,
+			Value: "'c'",
+		}, matched)
+		assert.NoError(t, err)
+	})
+	valid := []string{`'ä'`, `'本'`, `'\''`, `'\000'`, `'\007'`, `'\x07'`, `'\xff'`, `'\u12e4'`, `'\U00101234'`}
+	for _, input := range valid {
+		t.Run(input, func(t *testing.T) {
+			_, matched, err := parse(input)
+			assert.Equal(t, &ast.BasicLit{Kind: token.CHAR, Value: input}, matched)
+			assert.NoError(t, err)
+		})
+	}
+	invalid := []string{`'aa'`, `'\"'`, `'\k'`, `'\xa'`, `'\0'`, `'\400'`, `'\uDFFF'`, `'\U00110000'`, `''`, "'\n'"}
+	for _, input := range invalid {
+		t.Run(input, func(t *testing.T) {
+			_, _, err := parse(input)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestIncDecStmt(t *testing.T) {
+	parse := stringParser(IncDecStmt)
+	t.Run("inc", func(t *testing.T) {
+		_, matched, err := parse(`(inc i)`)
 		assert.Equal(t, &ast.IncDecStmt{
 			X:   ast.NewIdent("i"),
 			Tok: token.INC,
@@ -1005,6 +2313,142 @@ func TestForStmt(t *testing.T) {
 			}}}},
 		}, matched)
 	})
+	t.Run("omitted init and post", func(t *testing.T) {
+		_, matched, err := parse(`(for () (< i 10) () (inc i))`)
+		assert.NoError(t, err)
+		assert.Equal(t, &ast.ForStmt{
+			Cond: &ast.BinaryExpr{
+				X:  ast.NewIdent("i"),
+				Op: token.LSS,
+				Y:  intLit(10),
+			},
+			Body: &ast.BlockStmt{List: []ast.Stmt{&ast.IncDecStmt{
+				X:   ast.NewIdent("i"),
+				Tok: token.INC,
+			}}},
+		}, matched)
+	})
+	t.Run("cond only", func(t *testing.T) {
+		_, matched, err := parse(`(for (< i 10) (inc i))`)
+		assert.NoError(t, err)
+		assert.Equal(t, &ast.ForStmt{
+			Cond: &ast.BinaryExpr{
+				X:  ast.NewIdent("i"),
+				Op: token.LSS,
+				Y:  intLit(10),
+			},
+			Body: &ast.BlockStmt{List: []ast.Stmt{&ast.IncDecStmt{
+				X:   ast.NewIdent("i"),
+				Tok: token.INC,
+			}}},
+		}, matched)
+	})
+	t.Run("infinite", func(t *testing.T) {
+		_, matched, err := parse(`(for (do (f) (break)))`)
+		assert.NoError(t, err)
+		assert.Equal(t, &ast.ForStmt{
+			Body: &ast.BlockStmt{List: []ast.Stmt{
+				&ast.ExprStmt{X: newCallExpr("f")},
+				&ast.BranchStmt{Tok: token.BREAK},
+			}},
+		}, matched)
+	})
+}
+
+func TestRangeStmt(t *testing.T) {
+	parse := stringParser(RangeStmt)
+	body := &ast.BlockStmt{List: []ast.Stmt{&ast.ExprStmt{X: newCallExpr("f")}}}
+	t.Run("key and value", func(t *testing.T) {
+		_, matched, err := parse(`(range (k v) m (f))`)
+		assert.NoError(t, err)
+		assert.Equal(t, &ast.RangeStmt{
+			Key:   ast.NewIdent("k"),
+			Value: ast.NewIdent("v"),
+			Tok:   token.DEFINE,
+			X:     ast.NewIdent("m"),
+			Body:  body,
+		}, matched)
+	})
+	t.Run("key only", func(t *testing.T) {
+		_, matched, err := parse(`(range i xs (f))`)
+		assert.NoError(t, err)
+		assert.Equal(t, &ast.RangeStmt{
+			Key:  ast.NewIdent("i"),
+			Tok:  token.DEFINE,
+			X:    ast.NewIdent("xs"),
+			Body: body,
+		}, matched)
+	})
+	t.Run("blank key", func(t *testing.T) {
+		_, matched, err := parse(`(range (_ r) "hello" (f))`)
+		assert.NoError(t, err)
+		assert.Equal(t, &ast.RangeStmt{
+			Key:   ast.NewIdent("_"),
+			Value: ast.NewIdent("r"),
+			Tok:   token.DEFINE,
+			X:     strLit(`"hello"`),
+			Body:  body,
+		}, matched)
+	})
+	t.Run("no variables", func(t *testing.T) {
+		_, matched, err := parse(`(range () ch (f))`)
+		assert.NoError(t, err)
+		assert.Equal(t, &ast.RangeStmt{
+			X:    ast.NewIdent("ch"),
+			Body: body,
+		}, matched)
+	})
+	t.Run("integer", func(t *testing.T) {
+		_, matched, err := parse(`(range i 10 (f))`)
+		assert.NoError(t, err)
+		assert.Equal(t, &ast.RangeStmt{
+			Key:  ast.NewIdent("i"),
+			Tok:  token.DEFINE,
+			X:    intLit(10),
+			Body: body,
+		}, matched)
+	})
+	t.Run("too many variables", func(t *testing.T) {
+		_, _, err := parse(`(range (a b c) xs (f))`)
+		assert.Error(t, err)
+	})
+}
+
+func TestBranchStmt(t *testing.T) {
+	parse := stringParser(BranchStmt)
+	t.Run("break", func(t *testing.T) {
+		_, matched, err := parse(`(break)`)
+		assert.NoError(t, err)
+		assert.Equal(t, &ast.BranchStmt{Tok: token.BREAK}, matched)
+	})
+	t.Run("continue with label", func(t *testing.T) {
+		_, matched, err := parse(`(continue outer)`)
+		assert.NoError(t, err)
+		assert.Equal(t, &ast.BranchStmt{Tok: token.CONTINUE, Label: ast.NewIdent("outer")}, matched)
+	})
+	t.Run("fallthrough", func(t *testing.T) {
+		_, matched, err := parse(`(fallthrough)`)
+		assert.NoError(t, err)
+		assert.Equal(t, &ast.BranchStmt{Tok: token.FALLTHROUGH}, matched)
+	})
+	t.Run("fallthrough with label", func(t *testing.T) {
+		_, _, err := parse(`(fallthrough outer)`)
+		assert.Error(t, err)
+	})
+}
+
+func TestLabeledStmt(t *testing.T) {
+	parse := stringParser(LabeledStmt)
+	_, matched, err := parse(`(label outer (for (break outer)))`)
+	assert.NoError(t, err)
+	assert.Equal(t, &ast.LabeledStmt{
+		Label: ast.NewIdent("outer"),
+		Stmt: &ast.ForStmt{
+			Body: &ast.BlockStmt{List: []ast.Stmt{
+				&ast.BranchStmt{Tok: token.BREAK, Label: ast.NewIdent("outer")},
+			}},
+		},
+	}, matched)
 }
 
 func TestAssignment(t *testing.T) {
@@ -1027,6 +2471,51 @@ func TestAssignment(t *testing.T) {
 			},
 		}, matched)
 	})
+	t.Run("single index target", func(t *testing.T) {
+		_, matched, err := parse(`(assign (index xs i) v)`)
+		assert.NoError(t, err)
+		assert.Equal(t, &ast.AssignStmt{
+			Lhs: []ast.Expr{&ast.IndexExpr{X: ast.NewIdent("xs"), Index: ast.NewIdent("i")}},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{ast.NewIdent("v")},
+		}, matched)
+	})
+	t.Run("unassignable targets", func(t *testing.T) {
+		for _, input := range []string{`(assign 1 2)`, `(assign (x 1) (1 2))`, `(assign ((f x)) 1)`} {
+			_, _, err := parse(input)
+			assert.Error(t, err, input)
+		}
+	})
+	t.Run("index target", func(t *testing.T) {
+		_, matched, err := parse(`(assign ((index xs i) (index m k)) (1 2))`)
+		assert.NoError(t, err)
+		assert.Equal(t, &ast.AssignStmt{
+			Lhs: []ast.Expr{
+				&ast.IndexExpr{X: ast.NewIdent("xs"), Index: ast.NewIdent("i")},
+				&ast.IndexExpr{X: ast.NewIdent("m"), Index: ast.NewIdent("k")},
+			},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{intLit(1), intLit(2)},
+		}, matched)
+	})
+	t.Run("field target", func(t *testing.T) {
+		_, matched, err := parse(`(assign p.X 1)`)
+		assert.NoError(t, err)
+		assert.Equal(t, &ast.AssignStmt{
+			Lhs: []ast.Expr{newSelectorExpr("p", "X")},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{intLit(1)},
+		}, matched)
+	})
+	t.Run("pointer target", func(t *testing.T) {
+		_, matched, err := parse(`(assign *ptr -v)`)
+		assert.NoError(t, err)
+		assert.Equal(t, &ast.AssignStmt{
+			Lhs: []ast.Expr{&ast.StarExpr{X: ast.NewIdent("ptr")}},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{&ast.UnaryExpr{Op: token.SUB, X: ast.NewIdent("v")}},
+		}, matched)
+	})
 	t.Run("single expression", func(t *testing.T) {
 		_, matched, err := parse(`(assign x ((+ x 1)))`)
 		assert.NoError(t, err)
@@ -1153,4 +2642,590 @@ func TestExprSwitchStmt(t *testing.T) {
 			}, matched)
 		}
 	})
+	t.Run("tag", func(t *testing.T) {
+		_, matched, err := parse(`(switch x (case (1 2) (fallthrough)) (default (println x)))`)
+		if assert.NoError(t, err) {
+			assert.Equal(t, &ast.SwitchStmt{
+				Tag: ast.NewIdent("x"),
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.CaseClause{
+							List: []ast.Expr{intLit(1), intLit(2)},
+							Body: []ast.Stmt{&ast.BranchStmt{Tok: token.FALLTHROUGH}},
+						},
+						&ast.CaseClause{
+							Body: []ast.Stmt{&ast.ExprStmt{X: newCallExpr("println", ast.NewIdent("x"))}},
+						},
+					},
+				},
+			}, matched)
+		}
+	})
+	t.Run("init and tag", func(t *testing.T) {
+		_, matched, err := parse(`(switch (define x ((f))) x (case 1 (println x)))`)
+		if assert.NoError(t, err) {
+			assert.Equal(t, &ast.SwitchStmt{
+				Init: &ast.AssignStmt{
+					Lhs: []ast.Expr{ast.NewIdent("x")},
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{newCallExpr("f")},
+				},
+				Tag: ast.NewIdent("x"),
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.CaseClause{
+							List: []ast.Expr{intLit(1)},
+							Body: []ast.Stmt{&ast.ExprStmt{X: newCallExpr("println", ast.NewIdent("x"))}},
+						},
+					},
+				},
+			}, matched)
+		}
+	})
+	t.Run("init without tag", func(t *testing.T) {
+		_, matched, err := parse(`(switch (inc i) (case ((> i 1)) (println i)))`)
+		if assert.NoError(t, err) {
+			assert.Equal(t, &ast.SwitchStmt{
+				Init: &ast.IncDecStmt{X: ast.NewIdent("i"), Tok: token.INC},
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.CaseClause{
+							List: []ast.Expr{&ast.BinaryExpr{X: ast.NewIdent("i"), Op: token.GTR, Y: intLit(1)}},
+							Body: []ast.Stmt{&ast.ExprStmt{X: newCallExpr("println", ast.NewIdent("i"))}},
+						},
+					},
+				},
+			}, matched)
+		}
+	})
+	t.Run("call as tag", func(t *testing.T) {
+		_, matched, err := parse(`(switch (f) (default (g)))`)
+		if assert.NoError(t, err) {
+			assert.Equal(t, &ast.SwitchStmt{
+				Tag: newCallExpr("f"),
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.CaseClause{Body: []ast.Stmt{&ast.ExprStmt{X: newCallExpr("g")}}},
+					},
+				},
+			}, matched)
+		}
+	})
+}
+
+func TestReturnStmt(t *testing.T) {
+	parse := stringParser(ReturnStmt)
+	t.Run("bare", func(t *testing.T) {
+		_, matched, err := parse(`(return)`)
+		assert.Equal(t, &ast.ReturnStmt{}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("single value", func(t *testing.T) {
+		_, matched, err := parse(`(return (+ a b))`)
+		assert.Equal(t, &ast.ReturnStmt{
+			Results: []ast.Expr{
+				&ast.BinaryExpr{
+					X:  ast.NewIdent("a"),
+					Op: token.ADD,
+					Y:  ast.NewIdent("b"),
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("multiple values", func(t *testing.T) {
+		_, matched, err := parse(`(return x err)`)
+		assert.Equal(t, &ast.ReturnStmt{
+			Results: []ast.Expr{ast.NewIdent("x"), ast.NewIdent("err")},
+		}, matched)
+		assert.NoError(t, err)
+	})
+}
+
+func TestMethodDecl(t *testing.T) {
+	parse := stringParser(MethodDecl)
+	t.Run("doc string", func(t *testing.T) {
+		_, matched, err := parse(`(method (p Point) String "String formats p." () (results string) (return "point"))`)
+		assert.NoError(t, err)
+		assert.Equal(t, &ast.CommentGroup{List: []*ast.Comment{{Text: "// String formats p."}}}, matched.(*ast.FuncDecl).Doc)
+		assert.Equal(t, ast.NewIdent("String"), matched.(*ast.FuncDecl).Name)
+	})
+	t.Run("pointer receiver", func(t *testing.T) {
+		_, matched, err := parse(`(method (p *Point) Dist () (results float64) (return p.X))`)
+		assert.Equal(t, &ast.FuncDecl{
+			Recv: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("p")},
+						Type:  &ast.StarExpr{X: ast.NewIdent("Point")},
+					},
+				},
+			},
+			Name: ast.NewIdent("Dist"),
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{},
+				Results: &ast.FieldList{
+					List: []*ast.Field{{Type: ast.NewIdent("float64")}},
+				},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{Results: []ast.Expr{newSelectorExpr("p", "X")}},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("value receiver", func(t *testing.T) {
+		_, matched, err := parse(`(method (p Point) String () (results string) (return "point"))`)
+		assert.Equal(t, &ast.FuncDecl{
+			Recv: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("p")},
+						Type:  ast.NewIdent("Point"),
+					},
+				},
+			},
+			Name: ast.NewIdent("String"),
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{},
+				Results: &ast.FieldList{
+					List: []*ast.Field{{Type: ast.NewIdent("string")}},
+				},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{Results: []ast.Expr{strLit(`"point"`)}},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+}
+
+func TestFuncLit(t *testing.T) {
+	parse := stringParser(FuncLit)
+	t.Run("call statements are not a result list", func(t *testing.T) {
+		_, matched, err := parse(`(fn () (foo) (bar))`)
+		assert.Equal(t, &ast.FuncLit{
+			Type: &ast.FuncType{Params: &ast.FieldList{}},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ExprStmt{X: newCallExpr("foo")},
+					&ast.ExprStmt{X: newCallExpr("bar")},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("no parameters", func(t *testing.T) {
+		_, matched, err := parse(`(fn () (println 1))`)
+		assert.Equal(t, &ast.FuncLit{
+			Type: &ast.FuncType{Params: &ast.FieldList{}},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ExprStmt{X: newCallExpr("println", intLit(1))},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("parameters and result", func(t *testing.T) {
+		_, matched, err := parse(`(fn ((x int)) (results int) (return (* x x)))`)
+		assert.Equal(t, &ast.FuncLit{
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{
+					List: []*ast.Field{
+						{Names: []*ast.Ident{ast.NewIdent("x")}, Type: ast.NewIdent("int")},
+					},
+				},
+				Results: &ast.FieldList{
+					List: []*ast.Field{{Type: ast.NewIdent("int")}},
+				},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{Results: []ast.Expr{
+						&ast.BinaryExpr{X: ast.NewIdent("x"), Op: token.MUL, Y: ast.NewIdent("x")},
+					}},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+}
+
+func Test_compositeLit_Parse(t *testing.T) {
+	parse := stringParser(CompositeLit)
+	t.Run("keyed struct", func(t *testing.T) {
+		_, matched, err := parse(`(lit Point (X: 1) (Y: 2))`)
+		assert.Equal(t, &ast.CompositeLit{
+			Type: ast.NewIdent("Point"),
+			Elts: []ast.Expr{
+				&ast.KeyValueExpr{Key: ast.NewIdent("X"), Value: intLit(1)},
+				&ast.KeyValueExpr{Key: ast.NewIdent("Y"), Value: intLit(2)},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("positional struct", func(t *testing.T) {
+		_, matched, err := parse(`(lit image.Point x (+ y 1))`)
+		assert.Equal(t, &ast.CompositeLit{
+			Type: newSelectorExpr("image", "Point"),
+			Elts: []ast.Expr{
+				ast.NewIdent("x"),
+				&ast.BinaryExpr{X: ast.NewIdent("y"), Op: token.ADD, Y: intLit(1)},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("empty", func(t *testing.T) {
+		_, matched, err := parse(`(lit []int)`)
+		assert.Equal(t, &ast.CompositeLit{
+			Type: &ast.ArrayType{Elt: ast.NewIdent("int")},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("map", func(t *testing.T) {
+		_, matched, err := parse(`(lit map[string]int ("a": 1) ("b": 2))`)
+		assert.Equal(t, &ast.CompositeLit{
+			Type: &ast.MapType{Key: ast.NewIdent("string"), Value: ast.NewIdent("int")},
+			Elts: []ast.Expr{
+				&ast.KeyValueExpr{Key: strLit(`"a"`), Value: intLit(1)},
+				&ast.KeyValueExpr{Key: strLit(`"b"`), Value: intLit(2)},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("elided types", func(t *testing.T) {
+		_, matched, err := parse(`(lit []*Point (lit _ 1 2) (lit _ (X: 3)))`)
+		assert.Equal(t, &ast.CompositeLit{
+			Type: &ast.ArrayType{Elt: &ast.StarExpr{X: ast.NewIdent("Point")}},
+			Elts: []ast.Expr{
+				&ast.CompositeLit{Elts: []ast.Expr{intLit(1), intLit(2)}},
+				&ast.CompositeLit{Elts: []ast.Expr{
+					&ast.KeyValueExpr{Key: ast.NewIdent("X"), Value: intLit(3)},
+				}},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("address of elided types", func(t *testing.T) {
+		_, matched, err := parse(`(lit []*T &(lit _ (Name: "y")) &(lit _ (Name: "z")))`)
+		assert.Equal(t, &ast.CompositeLit{
+			Type: &ast.ArrayType{Elt: &ast.StarExpr{X: ast.NewIdent("T")}},
+			Elts: []ast.Expr{
+				&ast.CompositeLit{Elts: []ast.Expr{
+					&ast.KeyValueExpr{Key: ast.NewIdent("Name"), Value: strLit(`"y"`)},
+				}},
+				&ast.CompositeLit{Elts: []ast.Expr{
+					&ast.KeyValueExpr{Key: ast.NewIdent("Name"), Value: strLit(`"z"`)},
+				}},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+}
+
+func Test_indexExpr_Parse(t *testing.T) {
+	parse := stringParser(IndexExpr)
+	t.Run("single index", func(t *testing.T) {
+		_, matched, err := parse(`(index xs (+ i 1))`)
+		assert.Equal(t, &ast.IndexExpr{
+			X:     ast.NewIdent("xs"),
+			Index: &ast.BinaryExpr{X: ast.NewIdent("i"), Op: token.ADD, Y: intLit(1)},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("map lookup", func(t *testing.T) {
+		_, matched, err := parse(`(index m "key")`)
+		assert.Equal(t, &ast.IndexExpr{
+			X:     ast.NewIdent("m"),
+			Index: strLit(`"key"`),
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("generic instantiation", func(t *testing.T) {
+		_, matched, err := parse(`(index Map string []int)`)
+		assert.Equal(t, &ast.IndexListExpr{
+			X: ast.NewIdent("Map"),
+			Indices: []ast.Expr{
+				ast.NewIdent("string"),
+				&ast.ArrayType{Elt: ast.NewIdent("int")},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+}
+
+func Test_sliceExpr_Parse(t *testing.T) {
+	parse := stringParser(SliceExpr)
+	t.Run("low and high", func(t *testing.T) {
+		_, matched, err := parse(`(slice s 1 3)`)
+		assert.Equal(t, &ast.SliceExpr{
+			X:    ast.NewIdent("s"),
+			Low:  intLit(1),
+			High: intLit(3),
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("low only", func(t *testing.T) {
+		_, matched, err := parse(`(slice s 1)`)
+		assert.Equal(t, &ast.SliceExpr{
+			X:   ast.NewIdent("s"),
+			Low: intLit(1),
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("high only", func(t *testing.T) {
+		_, matched, err := parse(`(slice s _ (len s))`)
+		assert.Equal(t, &ast.SliceExpr{
+			X:    ast.NewIdent("s"),
+			High: newCallExpr("len", ast.NewIdent("s")),
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("full slice", func(t *testing.T) {
+		_, matched, err := parse(`(slice s _ 3 5)`)
+		assert.Equal(t, &ast.SliceExpr{
+			X:      ast.NewIdent("s"),
+			High:   intLit(3),
+			Max:    intLit(5),
+			Slice3: true,
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("no bounds", func(t *testing.T) {
+		_, matched, err := parse(`(slice arr)`)
+		assert.Equal(t, &ast.SliceExpr{
+			X: ast.NewIdent("arr"),
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("max without high", func(t *testing.T) {
+		_, _, err := parse(`(slice s 1 _ 3)`)
+		assert.Error(t, err)
+	})
+}
+
+func TestGoStmt(t *testing.T) {
+	parse := stringParser(GoStmt)
+	t.Run("call", func(t *testing.T) {
+		_, matched, err := parse(`(go (worker ch))`)
+		assert.Equal(t, &ast.GoStmt{
+			Call: newCallExpr("worker", ast.NewIdent("ch")),
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("not a call", func(t *testing.T) {
+		_, _, err := parse(`(go worker)`)
+		assert.Error(t, err)
+	})
+}
+
+func TestSendStmt(t *testing.T) {
+	parse := stringParser(SendStmt)
+	_, matched, err := parse(`(send ch (+ x 1))`)
+	assert.Equal(t, &ast.SendStmt{
+		Chan:  ast.NewIdent("ch"),
+		Value: &ast.BinaryExpr{X: ast.NewIdent("x"), Op: token.ADD, Y: intLit(1)},
+	}, matched)
+	assert.NoError(t, err)
+}
+
+func TestSelectStmt(t *testing.T) {
+	parse := stringParser(SelectStmt)
+	t.Run("no clauses", func(t *testing.T) {
+		_, matched, err := parse(`(select)`)
+		assert.Equal(t, &ast.SelectStmt{Body: &ast.BlockStmt{}}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("all clauses", func(t *testing.T) {
+		_, matched, err := parse(`(select
+    (recv (v ok) in (println v ok))
+    (recv () done (return))
+    (send out 1 (println "sent"))
+    (default (println "idle")))`)
+		assert.Equal(t, &ast.SelectStmt{
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.CommClause{
+						Comm: &ast.AssignStmt{
+							Lhs: []ast.Expr{ast.NewIdent("v"), ast.NewIdent("ok")},
+							Tok: token.DEFINE,
+							Rhs: []ast.Expr{&ast.UnaryExpr{Op: token.ARROW, X: ast.NewIdent("in")}},
+						},
+						Body: []ast.Stmt{
+							&ast.ExprStmt{X: newCallExpr("println", ast.NewIdent("v"), ast.NewIdent("ok"))},
+						},
+					},
+					&ast.CommClause{
+						Comm: &ast.ExprStmt{X: &ast.UnaryExpr{Op: token.ARROW, X: ast.NewIdent("done")}},
+						Body: []ast.Stmt{&ast.ReturnStmt{}},
+					},
+					&ast.CommClause{
+						Comm: &ast.SendStmt{Chan: ast.NewIdent("out"), Value: intLit(1)},
+						Body: []ast.Stmt{
+							&ast.ExprStmt{X: newCallExpr("println", strLit(`"sent"`))},
+						},
+					},
+					&ast.CommClause{
+						Body: []ast.Stmt{
+							&ast.ExprStmt{X: newCallExpr("println", strLit(`"idle"`))},
+						},
+					},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+}
+
+func TestDeferStmt(t *testing.T) {
+	parse := stringParser(DeferStmt)
+	t.Run("method call", func(t *testing.T) {
+		_, matched, err := parse(`(defer (f.Close))`)
+		assert.Equal(t, &ast.DeferStmt{
+			Call: &ast.CallExpr{Fun: newSelectorExpr("f", "Close")},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("function literal", func(t *testing.T) {
+		_, matched, err := parse(`(defer ((fn () (recover))))`)
+		assert.Equal(t, &ast.DeferStmt{
+			Call: &ast.CallExpr{
+				Fun: &ast.FuncLit{
+					Type: &ast.FuncType{Params: &ast.FieldList{}},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{&ast.ExprStmt{X: newCallExpr("recover")}},
+					},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("not a call", func(t *testing.T) {
+		_, _, err := parse(`(defer f)`)
+		assert.Error(t, err)
+	})
+}
+
+func Test_typeAssertExpr_Parse(t *testing.T) {
+	parse := stringParser(TypeAssertExpr)
+	t.Run("named type", func(t *testing.T) {
+		_, matched, err := parse(`(type-assert x int)`)
+		assert.Equal(t, &ast.TypeAssertExpr{X: ast.NewIdent("x"), Type: ast.NewIdent("int")}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("pointer type", func(t *testing.T) {
+		_, matched, err := parse(`(type-assert err *os.PathError)`)
+		assert.Equal(t, &ast.TypeAssertExpr{
+			X:    ast.NewIdent("err"),
+			Type: &ast.StarExpr{X: newSelectorExpr("os", "PathError")},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("comma ok", func(t *testing.T) {
+		_, matched, err := stringParser(Define)(`(define (v ok) ((type-assert x string)))`)
+		assert.Equal(t, &ast.AssignStmt{
+			Lhs: []ast.Expr{ast.NewIdent("v"), ast.NewIdent("ok")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.TypeAssertExpr{X: ast.NewIdent("x"), Type: ast.NewIdent("string")}},
+		}, matched)
+		assert.NoError(t, err)
+	})
+}
+
+func TestTypeSwitchStmt(t *testing.T) {
+	parse := stringParser(TypeSwitchStmt)
+	t.Run("bound guard", func(t *testing.T) {
+		_, matched, err := parse(`(type-switch (v x)
+    (case (int string) (println v))
+    (case nil (return))
+    (default (panic v)))`)
+		assert.Equal(t, &ast.TypeSwitchStmt{
+			Assign: &ast.AssignStmt{
+				Lhs: []ast.Expr{ast.NewIdent("v")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{&ast.TypeAssertExpr{X: ast.NewIdent("x")}},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.CaseClause{
+						List: []ast.Expr{ast.NewIdent("int"), ast.NewIdent("string")},
+						Body: []ast.Stmt{&ast.ExprStmt{X: newCallExpr("println", ast.NewIdent("v"))}},
+					},
+					&ast.CaseClause{
+						List: []ast.Expr{ast.NewIdent("nil")},
+						Body: []ast.Stmt{&ast.ReturnStmt{}},
+					},
+					&ast.CaseClause{
+						Body: []ast.Stmt{&ast.ExprStmt{X: newCallExpr("panic", ast.NewIdent("v"))}},
+					},
+				},
+			},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("unbound guard", func(t *testing.T) {
+		_, matched, err := parse(`(type-switch x)`)
+		assert.Equal(t, &ast.TypeSwitchStmt{
+			Assign: &ast.ExprStmt{X: &ast.TypeAssertExpr{X: ast.NewIdent("x")}},
+			Body:   &ast.BlockStmt{},
+		}, matched)
+		assert.NoError(t, err)
+	})
+	t.Run("unbound complex guard", func(t *testing.T) {
+		_, matched, err := parse(`(type-switch (_ (f)))`)
+		assert.Equal(t, &ast.TypeSwitchStmt{
+			Assign: &ast.ExprStmt{X: &ast.TypeAssertExpr{X: newCallExpr("f")}},
+			Body:   &ast.BlockStmt{},
+		}, matched)
+		assert.NoError(t, err)
+	})
+}
+
+func TestCompoundAssignment(t *testing.T) {
+	parse := stringParser(CompoundAssignment)
+	tests := []struct {
+		op   string
+		want token.Token
+	}{
+		{"+=", token.ADD_ASSIGN},
+		{"-=", token.SUB_ASSIGN},
+		{"*=", token.MUL_ASSIGN},
+		{"/=", token.QUO_ASSIGN},
+		{"%=", token.REM_ASSIGN},
+		{"&=", token.AND_ASSIGN},
+		{"|=", token.OR_ASSIGN},
+		{"^=", token.XOR_ASSIGN},
+		{"<<=", token.SHL_ASSIGN},
+		{">>=", token.SHR_ASSIGN},
+		{"&^=", token.AND_NOT_ASSIGN},
+	}
+	for _, tt := range tests {
+		t.Run(tt.op, func(t *testing.T) {
+			_, matched, err := parse("(" + tt.op + " x y)")
+			assert.NoError(t, err)
+			assert.Equal(t, &ast.AssignStmt{
+				Lhs: []ast.Expr{ast.NewIdent("x")},
+				Tok: tt.want,
+				Rhs: []ast.Expr{ast.NewIdent("y")},
+			}, matched)
+		})
+	}
+	t.Run("unassignable target", func(t *testing.T) {
+		_, _, err := parse(`(+= 1 2)`)
+		assert.Error(t, err)
+	})
+	t.Run("index target", func(t *testing.T) {
+		_, matched, err := parse(`(+= (index xs i) (* 2 v))`)
+		assert.NoError(t, err)
+		assert.Equal(t, &ast.AssignStmt{
+			Lhs: []ast.Expr{&ast.IndexExpr{X: ast.NewIdent("xs"), Index: ast.NewIdent("i")}},
+			Tok: token.ADD_ASSIGN,
+			Rhs: []ast.Expr{&ast.BinaryExpr{X: intLit(2), Op: token.MUL, Y: ast.NewIdent("v")}},
+		}, matched)
+	})
 }